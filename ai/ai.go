@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/ai/azopenai"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -16,10 +18,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/bedrockruntime"
 	"github.com/aws/aws-sdk-go/service/bedrockruntime/bedrockruntimeiface"
 	"github.com/sashabaranov/go-openai"
-	"github.com/yuin/goldmark"
-	"github.com/yuin/goldmark/ast"
-	"github.com/yuin/goldmark/parser"
-	"github.com/yuin/goldmark/text"
 )
 
 type (
@@ -27,6 +25,9 @@ type (
 		Input       string
 		Instruction string
 		Options     any
+		// NextFunc, if set, is called with the result of this step. When it
+		// returns true, the returned step runs right after this one.
+		NextFunc func(prev *Result) (ChainParamsStep, bool)
 	}
 
 	ChainParams struct {
@@ -40,13 +41,22 @@ type (
 		openaiClient      *openai.Client
 		azureOpenAIClient *azopenai.Client
 		bedrockClient     bedrockruntimeiface.BedrockRuntimeAPI
+		httpClient        *http.Client
+		// ownTransport is the transport created by New, nil when Config.HTTPClient is set
+		ownTransport *http.Transport
 	}
 
 	Config struct {
 		// openai
 		OpenAIApiKey         string
+		OpenAIOrgID          string
 		OpenAIGptModel       string
 		OpenAIEmbeddingModel string
+		// OpenAIEmbeddingDimensions shortens the vectors of text-embedding-3 models,
+		// the model's full size is used when it's 0
+		OpenAIEmbeddingDimensions int
+		// OpenAIUseResponsesAPI sends chat requests to /responses instead of /chat/completions
+		OpenAIUseResponsesAPI bool
 
 		// azure openai
 		AzureOpenAIApiKey                string
@@ -61,8 +71,11 @@ type (
 		AwsBedrockEmbeddingModelArn string
 
 		// susanoo
-		SusanooEndpoint string
-		SusanooApiKey   string
+		SusanooEndpoint        string
+		SusanooApiKey          string
+		SusanooTimeout         time.Duration
+		SusanooPollInterval    time.Duration
+		SusanooPollMaxInterval time.Duration
 
 		// deepseek
 		DeepseekEndpoint string
@@ -70,6 +83,21 @@ type (
 		DeepseekApiKey   string
 
 		Provider string
+		// FallbackProviders are tried in order when a request to Provider fails.
+		// Each of them needs its own credentials and model configured.
+		FallbackProviders []string
+
+		// ExtraHeaders are added to every request sent to OpenAI-compatible
+		// APIs (openai, deepseek), e.g. a gateway's tenant header.
+		ExtraHeaders map[string]string
+
+		// HTTPClient is used for the HTTP requests to openai, deepseek and susanoo,
+		// e.g. to route them through a proxy. A client with its own transport is used when it's nil.
+		HTTPClient *http.Client
+
+		// TokenCounter counts the tokens of text for model, e.g. a tiktoken
+		// encoder. A rough approximation is used when it's nil.
+		TokenCounter func(text, model string) int
 
 		Debug bool
 	}
@@ -77,14 +105,42 @@ type (
 	GeneralChatCompletionMessage struct {
 		Role    string `json:"role"`
 		Content string `json:"content"`
+		// Parts carries extra content such as images for vision models.
+		// Only the openai and bedrock providers use it.
+		Parts []GeneralChatCompletionMessagePart `json:"-"`
+	}
+
+	GeneralChatCompletionMessagePart struct {
+		Type string
+		Text string
+		// ImageURL is an http(s) URL or a data URL
+		ImageURL string
+		// ImageBase64 is the raw base64 image data, MediaType is its mime type
+		ImageBase64 string
+		MediaType   string
 	}
 
 	Result struct {
 		Text string
 		Json map[string]any
+		// Provider is the provider that answered the request
+		Provider string
+		// Usage is the token usage of the request, summed over all steps in a chain
+		Usage Usage
+		// StepUsages is the token usage of each step, only set by CallInChain
+		StepUsages []Usage
+		// Raw is the response body returned by the provider, only set when
+		// cfg.Debug is true for openai, deepseek, susanoo and bedrock.
+		// It's also returned with the error when the provider fails.
+		Raw []byte
 	}
 )
 
+const (
+	MessagePartTypeText  = "text"
+	MessagePartTypeImage = "image"
+)
+
 const (
 	ProviderAzure    = "azure"
 	ProviderOpenAI   = "openai"
@@ -94,17 +150,87 @@ const (
 )
 
 func (m GeneralChatCompletionMessage) Pretty() string {
+	if len(m.Parts) != 0 {
+		return fmt.Sprintf("{ Role: '%s', Content: '%s', Parts: %d }", m.Role, m.Content, len(m.Parts))
+	}
 	return fmt.Sprintf("{ Role: '%s', Content: '%s' }", m.Role, m.Content)
 }
 
+// Unmarshal decodes the Json of the result into the struct pointed to by ptr.
+func (r *Result) Unmarshal(ptr any) error {
+	if r == nil || r.Json == nil {
+		return fmt.Errorf("result has no json output")
+	}
+	data, err := json.Marshal(r.Json)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json output: %w", err)
+	}
+	if err := json.Unmarshal(data, ptr); err != nil {
+		return fmt.Errorf("failed to unmarshal json output: %w", err)
+	}
+	return nil
+}
+
+// dataURL returns the image of the part as a URL, encoding base64 data as a data URL.
+func (p GeneralChatCompletionMessagePart) dataURL() string {
+	if p.ImageBase64 != "" {
+		return fmt.Sprintf("data:%s;base64,%s", p.MediaType, p.ImageBase64)
+	}
+	return p.ImageURL
+}
+
+// base64Image returns the media type and base64 data of the part's image.
+// It only works for base64 data and data URLs.
+func (p GeneralChatCompletionMessagePart) base64Image() (string, string, bool) {
+	if p.ImageBase64 != "" {
+		return p.MediaType, p.ImageBase64, true
+	}
+	if rest, ok := strings.CutPrefix(p.ImageURL, "data:"); ok {
+		meta, data, found := strings.Cut(rest, ",")
+		if found && strings.HasSuffix(meta, ";base64") {
+			return strings.TrimSuffix(meta, ";base64"), data, true
+		}
+	}
+	return "", "", false
+}
+
 func New(cfg Config) *Instant {
 	var openaiClient *openai.Client
 	var azureOpenAIClient *azopenai.Client
 	var bedrockClient bedrockruntimeiface.BedrockRuntimeAPI
 	var err error
 
+	var ownTransport *http.Transport
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		// don't share http.DefaultTransport, so Close only touches our connections
+		ownTransport = http.DefaultTransport.(*http.Transport).Clone()
+		httpClient = &http.Client{Transport: ownTransport}
+	}
+	if cfg.Debug {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		withCapture := *httpClient
+		withCapture.Transport = &rawCaptureTransport{base: base}
+		httpClient = &withCapture
+	}
+
 	if cfg.OpenAIApiKey != "" {
-		openaiClient = openai.NewClient(cfg.OpenAIApiKey)
+		openaiCfg := openai.DefaultConfig(cfg.OpenAIApiKey)
+		openaiCfg.OrgID = cfg.OpenAIOrgID
+		openaiCfg.HTTPClient = httpClient
+		if len(cfg.ExtraHeaders) != 0 {
+			base := httpClient.Transport
+			if base == nil {
+				base = http.DefaultTransport
+			}
+			withHeaders := *httpClient
+			withHeaders.Transport = &headerTransport{headers: cfg.ExtraHeaders, base: base}
+			openaiCfg.HTTPClient = &withHeaders
+		}
+		openaiClient = openai.NewClientWithConfig(openaiCfg)
 	}
 
 	if cfg.AzureOpenAIApiKey != "" && cfg.AzureOpenAIEndpoint != "" && cfg.AzureOpenAIGptDeploymentID != "" {
@@ -131,12 +257,35 @@ func New(cfg Config) *Instant {
 		cfg.DeepseekEndpoint = "https://api.deepseek.com"
 	}
 
+	if cfg.SusanooTimeout == 0 {
+		cfg.SusanooTimeout = time.Minute * 3
+	}
+	if cfg.SusanooPollInterval == 0 {
+		cfg.SusanooPollInterval = time.Second
+	}
+	if cfg.SusanooPollMaxInterval == 0 {
+		cfg.SusanooPollMaxInterval = cfg.SusanooPollInterval * 8
+	} else if cfg.SusanooPollMaxInterval < cfg.SusanooPollInterval {
+		cfg.SusanooPollMaxInterval = cfg.SusanooPollInterval
+	}
+
 	return &Instant{
 		cfg:               cfg,
 		openaiClient:      openaiClient,
 		azureOpenAIClient: azureOpenAIClient,
 		bedrockClient:     bedrockClient,
+		httpClient:        httpClient,
+		ownTransport:      ownTransport,
+	}
+}
+
+// Close releases the idle connections of the transport created by New, it implements io.Closer.
+// A client passed in Config.HTTPClient is owned by the caller and is left untouched.
+func (s *Instant) Close() error {
+	if s.ownTransport != nil {
+		s.ownTransport.CloseIdleConnections()
 	}
+	return nil
 }
 
 func (s *Instant) RawRequest(ctx context.Context, messages []GeneralChatCompletionMessage) (*Result, error) {
@@ -144,6 +293,13 @@ func (s *Instant) RawRequest(ctx context.Context, messages []GeneralChatCompleti
 }
 
 func (s *Instant) RawRequestWithParams(ctx context.Context, messages []GeneralChatCompletionMessage, params map[string]any) (*Result, error) {
+	if maxTokens, ok := intParam(params, "max_input_tokens"); ok {
+		model := s.currentModel()
+		messages = trimMessages(messages, maxTokens, func(m GeneralChatCompletionMessage) int {
+			return s.estimateMessageTokens(m, model)
+		})
+	}
+
 	if s.cfg.Debug {
 		slog.Info("[goutils.ai] RawRequest messages:")
 		for _, message := range messages {
@@ -151,26 +307,93 @@ func (s *Instant) RawRequestWithParams(ctx context.Context, messages []GeneralCh
 		}
 	}
 
+	var ret *Result
+	var err error
+	providers := append([]string{s.cfg.Provider}, s.cfg.FallbackProviders...)
+	for i, provider := range providers {
+		reqCtx, capture := ctx, (*rawCapture)(nil)
+		if s.cfg.Debug {
+			reqCtx, capture = withRawCapture(ctx)
+		}
+		ret, err = s.rawRequestWithProvider(reqCtx, provider, messages, params)
+		if capture != nil {
+			// keep the body on errors too, it's what explains a misbehaving provider
+			if ret == nil {
+				ret = &Result{}
+			}
+			ret.Raw = capture.Bytes()
+		}
+		if err == nil {
+			ret.Provider = provider
+			break
+		}
+		if ctx.Err() != nil || i == len(providers)-1 {
+			return ret, err
+		}
+		if capture != nil {
+			// the next provider replaces ret, so log the failed body here
+			slog.Warn("[goutils.ai] provider failed, falling back", "provider", provider, "next", providers[i+1], "error", err, "raw", string(ret.Raw))
+			continue
+		}
+		slog.Warn("[goutils.ai] provider failed, falling back", "provider", provider, "next", providers[i+1], "error", err)
+	}
+
+	if val, ok := params["strip_think"]; ok && val == true {
+		ret.Text = StripThinkTags(ret.Text)
+	}
+
+	if s.cfg.Debug {
+		slog.Info("[goutils.ai] RawRequest", "ret", ret)
+	}
+
+	return ret, nil
+}
+
+func (s *Instant) rawRequestWithProvider(ctx context.Context, provider string, messages []GeneralChatCompletionMessage, params map[string]any) (*Result, error) {
 	var text string
 	var ret = &Result{}
 	var err error
 
-	switch s.cfg.Provider {
+	switch provider {
 	case ProviderOpenAI:
+		if s.cfg.OpenAIUseResponsesAPI {
+			text, ret.Usage, err = s.openAIResponsesRawRequest(ctx, messages, openaiOptionsFromParams(params))
+			ret.Text = text
+			if err != nil {
+				return ret, err
+			}
+			break
+		}
+
 		_messages := make([]openai.ChatCompletionMessage, 0, len(messages))
 		for _, message := range messages {
+			if len(message.Parts) == 0 {
+				_messages = append(_messages, openai.ChatCompletionMessage{
+					Role:    message.Role,
+					Content: message.Content,
+				})
+				continue
+			}
+			parts := make([]openai.ChatMessagePart, 0, len(message.Parts)+1)
+			if message.Content != "" {
+				parts = append(parts, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: message.Content})
+			}
+			for _, part := range message.Parts {
+				if part.Type == MessagePartTypeImage {
+					parts = append(parts, openai.ChatMessagePart{
+						Type:     openai.ChatMessagePartTypeImageURL,
+						ImageURL: &openai.ChatMessageImageURL{URL: part.dataURL()},
+					})
+				} else {
+					parts = append(parts, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: part.Text})
+				}
+			}
 			_messages = append(_messages, openai.ChatCompletionMessage{
-				Role:    message.Role,
-				Content: message.Content,
+				Role:         message.Role,
+				MultiContent: parts,
 			})
 		}
-		_opts := &OpenAIRawRequestOptions{}
-		if val, ok := params["format"]; ok {
-			if val == "json" {
-				_opts.UseJSON = true
-			}
-		}
-		text, err = s.OpenAIRawRequest(ctx, _messages, _opts)
+		text, ret.Usage, err = s.openAIRawRequest(ctx, _messages, openaiOptionsFromParams(params))
 		if err != nil {
 			ret.Text = text
 			return ret, err
@@ -180,7 +403,11 @@ func (s *Instant) RawRequestWithParams(ctx context.Context, messages []GeneralCh
 	case ProviderAzure:
 		_messages := make([]azopenai.ChatRequestMessageClassification, 0, len(messages))
 		for _, message := range messages {
-			if message.Role == openai.ChatMessageRoleUser {
+			if message.Role == openai.ChatMessageRoleSystem {
+				_messages = append(_messages, &azopenai.ChatRequestSystemMessage{
+					Content: azopenai.NewChatRequestSystemMessageContent(message.Content),
+				})
+			} else if message.Role == openai.ChatMessageRoleUser {
 				_messages = append(_messages, &azopenai.ChatRequestUserMessage{
 					Content: azopenai.NewChatRequestUserMessageContent(message.Content),
 				})
@@ -196,7 +423,7 @@ func (s *Instant) RawRequestWithParams(ctx context.Context, messages []GeneralCh
 				_opts.UseJSON = true
 			}
 		}
-		text, err = s.AzureOpenAIRawRequest(ctx, _messages, _opts)
+		text, ret.Usage, err = s.azureOpenAIRawRequest(ctx, _messages, _opts)
 		if err != nil {
 			ret.Text = text
 			return ret, err
@@ -206,17 +433,37 @@ func (s *Instant) RawRequestWithParams(ctx context.Context, messages []GeneralCh
 	case ProviderBedrock:
 		_messages := make([]BedRockClaudeChatMessage, 0, len(messages))
 		for _, message := range messages {
-			_messages = append(_messages, BedRockClaudeChatMessage{
-				Role: message.Role,
-				Content: []BedRockClaudeMessageContent{
-					{
-						Type: "text",
-						Text: message.Content,
+			content := make([]BedRockClaudeMessageContent, 0, len(message.Parts)+1)
+			if message.Content != "" || len(message.Parts) == 0 {
+				content = append(content, BedRockClaudeMessageContent{
+					Type: "text",
+					Text: message.Content,
+				})
+			}
+			for _, part := range message.Parts {
+				if part.Type != MessagePartTypeImage {
+					content = append(content, BedRockClaudeMessageContent{Type: "text", Text: part.Text})
+					continue
+				}
+				mediaType, data, ok := part.base64Image()
+				if !ok {
+					return nil, fmt.Errorf("bedrock only supports base64 images")
+				}
+				content = append(content, BedRockClaudeMessageContent{
+					Type: "image",
+					Source: &BedRockClaudeImageSource{
+						Type:      "base64",
+						MediaType: mediaType,
+						Data:      data,
 					},
-				},
+				})
+			}
+			_messages = append(_messages, BedRockClaudeChatMessage{
+				Role:    message.Role,
+				Content: content,
 			})
 		}
-		text, err = s.BedrockClaudeRawRequestAWS(ctx, _messages)
+		text, ret.Usage, err = s.bedrockClaudeRawRequestAWS(ctx, _messages)
 		if err != nil {
 			ret.Text = text
 			return ret, err
@@ -248,24 +495,21 @@ func (s *Instant) RawRequestWithParams(ctx context.Context, messages []GeneralCh
 				_opts.UseJSON = true
 			}
 		}
-		text, err := s.DeepseekRawRequest(ctx, messages, _opts)
+		text, usage, err := s.deepseekRawRequest(ctx, messages, _opts)
 		if err != nil {
 			ret.Text = text
 			return ret, err
 		}
 		ret.Text = text
+		ret.Usage = usage
 
 	default:
-		return nil, fmt.Errorf("provider %s not supported", s.cfg.Provider)
+		return nil, fmt.Errorf("provider %s not supported", provider)
 	}
 
 	if err != nil {
 		return nil, err
 	}
-	if s.cfg.Debug {
-		slog.Info("[goutils.ai] RawRequest", "ret", ret)
-	}
-
 	return ret, nil
 }
 
@@ -294,12 +538,14 @@ func (s *Instant) MultipleSteps(ctx context.Context, params ChainParams) (*Resul
 				Options:     nil,
 				Input:       "",
 				Instruction: inst,
+				NextFunc:    step.NextFunc,
 			})
 		} else if step.Instruction != "" {
 			newSteps = append(newSteps, ChainParamsStep{
 				Options:     nil,
 				Input:       "",
 				Instruction: step.Instruction,
+				NextFunc:    step.NextFunc,
 			})
 		}
 	}
@@ -309,40 +555,54 @@ func (s *Instant) MultipleSteps(ctx context.Context, params ChainParams) (*Resul
 
 func (s *Instant) CallInChain(ctx context.Context, params ChainParams) (*Result, error) {
 	ret := &Result{}
+
+	finalParams := make(map[string]any, len(params.RawRequestParams)+1)
+	for k, v := range params.RawRequestParams {
+		finalParams[k] = v
+	}
+	if _, ok := finalParams["format"]; !ok {
+		finalParams["format"] = params.Format
+	}
+
+	steps := make([]ChainParamsStep, len(params.Steps))
+	copy(steps, params.Steps)
+
+	var resp *Result
+	var err error
 	conv := make([]GeneralChatCompletionMessage, 0)
-	for i := 0; i < len(params.Steps)-1; i++ {
+	for i := 0; i < len(steps); i++ {
 		conv = append(conv, GeneralChatCompletionMessage{
 			Role:    openai.ChatMessageRoleUser,
-			Content: params.Steps[i].Instruction,
+			Content: steps[i].Instruction,
 		})
 
-		resp, err := s.RawRequestWithParams(ctx, conv, params.RawRequestParams)
+		reqParams := params.RawRequestParams
+		if i == len(steps)-1 {
+			reqParams = finalParams
+		}
+
+		resp, err = s.RawRequestWithParams(ctx, conv, reqParams)
 		if err != nil {
 			return nil, err
 		}
+		ret.Usage.Add(resp.Usage)
+		ret.StepUsages = append(ret.StepUsages, resp.Usage)
 
 		conv = append(conv, GeneralChatCompletionMessage{
 			Role:    openai.ChatMessageRoleAssistant,
 			Content: resp.Text,
 		})
-	}
-
-	finalStep := params.Steps[len(params.Steps)-1]
-	conv = append(conv, GeneralChatCompletionMessage{
-		Role:    openai.ChatMessageRoleUser,
-		Content: finalStep.Instruction,
-	})
 
-	if params.RawRequestParams == nil {
-		params.RawRequestParams = make(map[string]any)
-	}
-	if _, ok := params.RawRequestParams["format"]; !ok {
-		params.RawRequestParams["format"] = params.Format
+		// let the step decide what comes next based on its output
+		if steps[i].NextFunc != nil {
+			if next, ok := steps[i].NextFunc(resp); ok {
+				steps = append(steps[:i+1], append([]ChainParamsStep{next}, steps[i+1:]...)...)
+			}
+		}
 	}
 
-	resp, err := s.RawRequestWithParams(ctx, conv, params.RawRequestParams)
-	if err != nil {
-		return nil, err
+	if resp == nil {
+		return nil, fmt.Errorf("no steps in chain")
 	}
 
 	if params.Format == "json" {
@@ -366,6 +626,12 @@ func (s *Instant) GrabJsonOutput(ctx context.Context, input string, outputKeys .
 	if err := json.Unmarshal([]byte(input), &resp); err != nil {
 		slog.Warn("[goutils.ai] GrabJsonOutput error, let's try to extract the result", "input", input, "error", err)
 
+		if block, ok := ExtractCodeBlock(input, "json"); ok {
+			if err := json.Unmarshal([]byte(block), &resp); err == nil {
+				return filterOutputKeys(resp, outputKeys), nil
+			}
+		}
+
 		// use regex to extract the json part
 		// it could be multiple lines
 		re := regexp.MustCompile(`(?s)\{.*?\}`)
@@ -381,20 +647,25 @@ func (s *Instant) GrabJsonOutput(ctx context.Context, input string, outputKeys .
 		}
 	}
 
+	return filterOutputKeys(resp, outputKeys), nil
+}
+
+// filterOutputKeys keeps outputKeys of resp, it returns nil if any of them is missing.
+func filterOutputKeys(resp map[string]any, outputKeys []string) map[string]any {
 	if len(outputKeys) == 0 {
-		return resp, nil
+		return resp
 	}
 
 	// check if the response is valid
 	outputs := make(map[string]any)
 	for _, outputKey := range outputKeys {
 		if val, ok := resp[outputKey]; !ok || val == "" {
-			return nil, nil
+			return nil
 		}
 		outputs[outputKey] = resp[outputKey]
 	}
 
-	return outputs, nil
+	return outputs
 }
 
 func (s *Instant) GrabJsonOutputFromMd(ctx context.Context, input string, ptrOutput interface{}) error {
@@ -403,13 +674,10 @@ func (s *Instant) GrabJsonOutputFromMd(ctx context.Context, input string, ptrOut
 
 		input = strings.TrimSpace(input)
 
-		if strings.Contains(input, "```json") {
-			trimed, err := extractJSONFromMarkdown(input)
-			if err != nil {
-				slog.Warn("[goutils.ai] GrabJsonOutputFromMd error", "error", err)
-			} else {
-				input = trimed
-			}
+		if block, ok := ExtractCodeBlock(input, "json"); ok {
+			input = block
+		} else if block, ok := ExtractCodeBlock(input, ""); ok {
+			input = block
 		}
 
 		if err := json.Unmarshal([]byte(input), ptrOutput); err != nil {
@@ -435,10 +703,9 @@ func (s *Instant) GetEmbeddings(ctx context.Context, input []string) ([]float32,
 	case ProviderBedrock:
 		return s.CreateEmbeddingBedrock(ctx, input)
 	case ProviderSusanoo:
-		// @TODO replace with susanoo embedding
-		vec, err := s.CreateEmbeddingAzureOpenAI(ctx, input)
+		vec, err := s.SusanooCreateEmbedding(ctx, input)
 		if err != nil {
-			slog.Error("[goutils.ai] CreateEmbeddingAzureOpenAI error", "error", err)
+			slog.Error("[goutils.ai] SusanooCreateEmbedding error", "error", err)
 			return nil, err
 		}
 		return vec, nil
@@ -446,47 +713,3 @@ func (s *Instant) GetEmbeddings(ctx context.Context, input []string) ([]float32,
 		return nil, fmt.Errorf("provider %s not supported for embeddings", s.cfg.Provider)
 	}
 }
-
-func extractJSONFromMarkdown(markdownContent string) (string, error) {
-	md := goldmark.New(
-		goldmark.WithParserOptions(
-			parser.WithAutoHeadingID(),
-		),
-	)
-
-	fmt.Printf("markdownContent: %v\n", markdownContent)
-
-	// Parse the markdown content
-	reader := text.NewReader([]byte(markdownContent))
-	doc := md.Parser().Parse(reader)
-
-	jsonContents := make([]string, 0)
-	// Traverse the AST to find JSON code blocks
-	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
-		if !entering {
-			return ast.WalkContinue, nil
-		}
-
-		// Check if the node is a fenced code block
-		if codeBlock, ok := n.(*ast.FencedCodeBlock); ok {
-			// Get the language info
-			lang := string(codeBlock.Language(reader.Source()))
-			if lang == "json" {
-				// Extract the content inside the code block
-				content := codeBlock.Text(reader.Source())
-				// Convert to string
-				jsonContent := string(content)
-				// Append to the list of JSON contents
-				jsonContents = append(jsonContents, jsonContent)
-				// Continue walking to find more JSON blocks
-			}
-		}
-
-		return ast.WalkContinue, nil
-	})
-
-	if len(jsonContents) == 0 {
-		slog.Error("[goutils.ai] No JSON code block found in the markdown content.")
-	}
-	return strings.Join(jsonContents, "\n"), nil
-}
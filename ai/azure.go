@@ -17,12 +17,18 @@ type (
 )
 
 func (s *Instant) AzureOpenAIRawRequest(ctx context.Context, messages []azopenai.ChatRequestMessageClassification, opts *AzureRawRequestOptions) (string, error) {
+	text, _, err := s.azureOpenAIRawRequest(ctx, messages, opts)
+	return text, err
+}
+
+func (s *Instant) azureOpenAIRawRequest(ctx context.Context, messages []azopenai.ChatRequestMessageClassification, opts *AzureRawRequestOptions) (string, Usage, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*180)
 	defer cancel()
 
 	resultChan := make(chan struct {
-		resp string
-		err  error
+		resp  string
+		usage Usage
+		err   error
 	})
 
 	go func() {
@@ -41,16 +47,18 @@ func (s *Instant) AzureOpenAIRawRequest(ctx context.Context, messages []azopenai
 
 		if err != nil {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: err}
 			return
 		}
 
 		if len(resp.Choices) == 0 {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: nil}
 			return
 		}
@@ -86,8 +94,9 @@ func (s *Instant) AzureOpenAIRawRequest(ctx context.Context, messages []azopenai
 					}
 					if err != nil {
 						resultChan <- struct {
-							resp string
-							err  error
+							resp  string
+							usage Usage
+							err   error
 						}{resp: "", err: err}
 						return
 					}
@@ -97,17 +106,19 @@ func (s *Instant) AzureOpenAIRawRequest(ctx context.Context, messages []azopenai
 
 		if !gotReply {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: nil}
 			return
 		}
 
 		ret := resp.Choices[0].Message.Content
 		resultChan <- struct {
-			resp string
-			err  error
-		}{resp: *ret, err: nil}
+			resp  string
+			usage Usage
+			err   error
+		}{resp: *ret, usage: usageFromAzure(resp.Usage), err: nil}
 	}()
 
 	select {
@@ -115,19 +126,19 @@ func (s *Instant) AzureOpenAIRawRequest(ctx context.Context, messages []azopenai
 		// Context was canceled or timed out
 		if errors.Is(ctx.Err(), context.Canceled) {
 			slog.Error("[goutils.ai] Azure Request canceled", "error", ctx.Err())
-			return "", fmt.Errorf("request canceled: %w", ctx.Err())
+			return "", Usage{}, fmt.Errorf("request canceled: %w", ctx.Err())
 		}
-		return "", fmt.Errorf("request failed: %w", ctx.Err())
+		return "", Usage{}, fmt.Errorf("request failed: %w", ctx.Err())
 	case result := <-resultChan:
 		if result.err != nil {
 			if errors.Is(result.err, context.Canceled) {
 				slog.Error("[goutils.ai] Azure Request canceled", "error", result.err)
-				return "", fmt.Errorf("request canceled: %w", result.err)
+				return "", Usage{}, fmt.Errorf("request canceled: %w", result.err)
 			}
 			slog.Error("[goutils.ai] Azure Request error", "error", result.err)
-			return "", result.err
+			return "", Usage{}, result.err
 		}
-		return result.resp, nil
+		return result.resp, result.usage, nil
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -19,8 +20,15 @@ type (
 	}
 
 	BedRockClaudeMessageContent struct {
-		Type string `json:"type"`
-		Text string `json:"text,omitempty"`
+		Type   string                    `json:"type"`
+		Text   string                    `json:"text,omitempty"`
+		Source *BedRockClaudeImageSource `json:"source,omitempty"`
+	}
+
+	BedRockClaudeImageSource struct {
+		Type      string `json:"type"`
+		MediaType string `json:"media_type"`
+		Data      string `json:"data"`
 	}
 
 	BedrockClaudeResponse struct {
@@ -36,31 +44,61 @@ type (
 )
 
 const (
+	ChatMessageRoleSystem    = "system"
 	ChatMessageRoleUser      = "user"
 	ChatMessageRoleAssistant = "assistant"
 )
 
+// bedrockClaudeRequestBody builds the Claude messages request. Claude rejects
+// system messages in the messages list, so they go to the top-level system field.
+func bedrockClaudeRequestBody(messages []BedRockClaudeChatMessage) map[string]interface{} {
+	system := make([]string, 0)
+	chat := make([]BedRockClaudeChatMessage, 0, len(messages))
+	for _, message := range messages {
+		if message.Role != ChatMessageRoleSystem {
+			chat = append(chat, message)
+			continue
+		}
+		for _, content := range message.Content {
+			if content.Type == "text" && content.Text != "" {
+				system = append(system, content.Text)
+			}
+		}
+	}
+
+	body := map[string]interface{}{
+		"anthropic_version": "bedrock-2023-05-31",
+		"max_tokens":        10000,
+		"messages":          chat,
+	}
+	if len(system) != 0 {
+		body["system"] = strings.Join(system, "\n\n")
+	}
+	return body
+}
+
 func (s *Instant) BedrockClaudeRawRequestAWS(ctx context.Context, messages []BedRockClaudeChatMessage) (string, error) {
+	text, _, err := s.bedrockClaudeRawRequestAWS(ctx, messages)
+	return text, err
+}
+
+func (s *Instant) bedrockClaudeRawRequestAWS(ctx context.Context, messages []BedRockClaudeChatMessage) (string, Usage, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*180)
 	defer cancel()
 
 	resultChan := make(chan struct {
-		resp string
-		err  error
+		resp  string
+		usage Usage
+		err   error
 	})
 
 	go func() {
-		body := map[string]interface{}{
-			"anthropic_version": "bedrock-2023-05-31",
-			"max_tokens":        10000,
-			"messages":          messages,
-		}
-
-		bodyBytes, err := json.Marshal(body)
+		bodyBytes, err := json.Marshal(bedrockClaudeRequestBody(messages))
 		if err != nil {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: fmt.Errorf("failed to marshal request body: %w", err)}
 			return
 		}
@@ -74,33 +112,39 @@ func (s *Instant) BedrockClaudeRawRequestAWS(ctx context.Context, messages []Bed
 
 		if err != nil {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: err}
 			return
 		}
 
+		captureRaw(ctx, resp.Body)
+
 		var r BedrockClaudeResponse
 		if err := json.Unmarshal([]byte(resp.Body), &r); err != nil {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: fmt.Errorf("failed to unmarshal response: %w", err)}
 			return
 		}
 
 		if len(r.Content) == 0 {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: nil}
 			return
 		}
 
 		resultChan <- struct {
-			resp string
-			err  error
-		}{resp: r.Content[0].Text, err: nil}
+			resp  string
+			usage Usage
+			err   error
+		}{resp: r.Content[0].Text, usage: usageFromBedrock(r.Usage), err: nil}
 	}()
 
 	select {
@@ -108,19 +152,19 @@ func (s *Instant) BedrockClaudeRawRequestAWS(ctx context.Context, messages []Bed
 		// Context was canceled or timed out
 		if errors.Is(ctx.Err(), context.Canceled) {
 			slog.Error("[goutils.ai] AWS Bedrock Request canceled", "error", ctx.Err())
-			return "", fmt.Errorf("request canceled: %w", ctx.Err())
+			return "", Usage{}, fmt.Errorf("request canceled: %w", ctx.Err())
 		}
-		return "", fmt.Errorf("request failed: %w", ctx.Err())
+		return "", Usage{}, fmt.Errorf("request failed: %w", ctx.Err())
 	case result := <-resultChan:
 		if result.err != nil {
 			if errors.Is(result.err, context.Canceled) {
 				slog.Error("[goutils.ai] AWS Bedrock Request canceled", "error", result.err)
-				return "", fmt.Errorf("request canceled: %w", result.err)
+				return "", Usage{}, fmt.Errorf("request canceled: %w", result.err)
 			}
 			slog.Error("[goutils.ai] AWS Bedrock Request error", "error", result.err)
-			return "", result.err
+			return "", Usage{}, result.err
 		}
-		return result.resp, nil
+		return result.resp, result.usage, nil
 	}
 }
 
@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+const classifyPrompt = `Classify the text into exactly one of the following labels:
+%s
+
+Respond with a JSON object like {"label": "<one of the labels>", "confidence": <a number between 0 and 1>}.
+Do not explain the answer.`
+
+// Classify asks the provider to pick the label that fits text best. It returns
+// the label, exactly as given in labels, and the confidence reported by the model.
+func (s *Instant) Classify(ctx context.Context, text string, labels []string) (string, float64, error) {
+	if len(labels) == 0 {
+		return "", 0, fmt.Errorf("no labels to classify into")
+	}
+
+	quoted := make([]string, 0, len(labels))
+	for _, label := range labels {
+		quoted = append(quoted, fmt.Sprintf("- %q", label))
+	}
+
+	ret, err := s.RawRequestWithParams(ctx, []GeneralChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: fmt.Sprintf(classifyPrompt, strings.Join(quoted, "\n")),
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: text,
+		},
+	}, map[string]any{"format": "json"})
+	if err != nil {
+		return "", 0, err
+	}
+
+	js := ret.Json
+	if len(js) == 0 {
+		if js, err = s.GrabJsonOutput(ctx, ret.Text); err != nil {
+			return "", 0, fmt.Errorf("failed to parse classification: %w", err)
+		}
+	}
+
+	label, _ := js["label"].(string)
+	label = strings.TrimSpace(label)
+	for _, candidate := range labels {
+		if strings.EqualFold(candidate, label) {
+			confidence, _ := js["confidence"].(float64)
+			return candidate, confidence, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unexpected classification label: %q", label)
+}
@@ -0,0 +1,74 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type rawCaptureKey struct{}
+
+// rawCapture keeps the body of the last HTTP response received for a request.
+type rawCapture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func withRawCapture(ctx context.Context) (context.Context, *rawCapture) {
+	c := &rawCapture{}
+	return context.WithValue(ctx, rawCaptureKey{}, c), c
+}
+
+func (c *rawCapture) Bytes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.buf.Len() == 0 {
+		return nil
+	}
+	return bytes.Clone(c.buf.Bytes())
+}
+
+func (c *rawCapture) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf.Reset()
+}
+
+func (c *rawCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Write(p)
+}
+
+// captureRaw records body as the raw response of the request, for providers
+// that are not called through the http client.
+func captureRaw(ctx context.Context, body []byte) {
+	if c, ok := ctx.Value(rawCaptureKey{}).(*rawCapture); ok {
+		c.reset()
+		c.Write(body)
+	}
+}
+
+// rawCaptureTransport copies response bodies into the rawCapture of the request context.
+type rawCaptureTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rawCaptureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if c, ok := req.Context().Value(rawCaptureKey{}).(*rawCapture); ok {
+		c.reset()
+		resp.Body = &teeReadCloser{Reader: io.TeeReader(resp.Body, c), Closer: resp.Body}
+	}
+	return resp, nil
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
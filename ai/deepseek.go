@@ -1,9 +1,7 @@
 package ai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -78,12 +76,18 @@ type (
 )
 
 func (s *Instant) DeepseekRawRequest(ctx context.Context, messages []GeneralChatCompletionMessage, opts *DeepseekRawRequestOptions) (string, error) {
+	text, _, err := s.deepseekRawRequest(ctx, messages, opts)
+	return text, err
+}
+
+func (s *Instant) deepseekRawRequest(ctx context.Context, messages []GeneralChatCompletionMessage, opts *DeepseekRawRequestOptions) (string, Usage, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
 	resultChan := make(chan struct {
-		resp string
-		err  error
+		resp  string
+		usage Usage
+		err   error
 	})
 
 	go func() {
@@ -107,71 +111,39 @@ func (s *Instant) DeepseekRawRequest(ctx context.Context, messages []GeneralChat
 			}
 		}
 
-		payloadJson, err := json.Marshal(payload)
-		if err != nil {
-			resultChan <- struct {
-				resp string
-				err  error
-			}{resp: "", err: fmt.Errorf("failed to marshal payload: %w", err)}
-			return
-		}
-
-		buf := bytes.NewBuffer(payloadJson)
-		apiUrl := fmt.Sprintf("%s/chat/completions", s.cfg.DeepseekEndpoint)
-
-		req, err := http.NewRequest(http.MethodPost, apiUrl, buf)
-		if err != nil {
-			resultChan <- struct {
-				resp string
-				err  error
-			}{resp: "", err: fmt.Errorf("failed to create request: %w", err)}
-			return
-		}
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("Accept", "application/json")
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.cfg.DeepseekApiKey))
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			resultChan <- struct {
-				resp string
-				err  error
-			}{resp: "", err: fmt.Errorf("failed to send request: %w", err)}
-			return
-		}
-		defer resp.Body.Close()
-		// buf1, _ := io.ReadAll(resp.Body)
-		// fmt.Printf("buf: %v\n", string(buf1))
-
 		var body DeepseekChatResponse
-		if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if err := s.doJSON(ctx, http.MethodPost, s.cfg.DeepseekEndpoint, "/chat/completions", s.deepseekHeaders(), payload, &body); err != nil {
 			resultChan <- struct {
-				resp string
-				err  error
-			}{resp: "", err: fmt.Errorf("failed to decode response: %w", err)}
+				resp  string
+				usage Usage
+				err   error
+			}{resp: "", err: err}
 			return
 		}
 
 		if body.Error.Code != "" {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: fmt.Errorf("deepseek error: %s, %s", body.Error.Code, body.Error.Message)}
 			return
 		}
 
 		if len(body.Choices) == 0 {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: fmt.Errorf("no choices in response")}
 			return
 		}
 
 		resultChan <- struct {
-			resp string
-			err  error
-		}{resp: body.Choices[0].Message.Content, err: nil}
+			resp  string
+			usage Usage
+			err   error
+		}{resp: body.Choices[0].Message.Content, usage: Usage{InputTokens: body.Usage.PromptTokens, OutputTokens: body.Usage.CompletionTokens, CacheReadTokens: body.Usage.PromptCacheHitTokens}, err: nil}
 	}()
 
 	select {
@@ -179,18 +151,28 @@ func (s *Instant) DeepseekRawRequest(ctx context.Context, messages []GeneralChat
 		// Context was canceled or timed out
 		if errors.Is(ctx.Err(), context.Canceled) {
 			slog.Error("[goutils.ai] deepseek request canceled", "error", ctx.Err())
-			return "", fmt.Errorf("request canceled: %w", ctx.Err())
+			return "", Usage{}, fmt.Errorf("request canceled: %w", ctx.Err())
 		}
-		return "", fmt.Errorf("request failed: %w", ctx.Err())
+		return "", Usage{}, fmt.Errorf("request failed: %w", ctx.Err())
 	case result := <-resultChan:
 		if result.err != nil {
 			if errors.Is(result.err, context.Canceled) {
 				slog.Error("[goutils.ai] deepseek request canceled", "error", result.err)
-				return "", fmt.Errorf("request canceled: %w", result.err)
+				return "", Usage{}, fmt.Errorf("request canceled: %w", result.err)
 			}
 			slog.Error("[goutils.ai] deepseek request error", "error", result.err)
-			return "", result.err
+			return "", Usage{}, result.err
 		}
-		return result.resp, nil
+		return result.resp, result.usage, nil
+	}
+}
+
+func (s *Instant) deepseekHeaders() map[string]string {
+	headers := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", s.cfg.DeepseekApiKey),
+	}
+	for k, v := range s.cfg.ExtraHeaders {
+		headers[k] = v
 	}
+	return headers
 }
@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lyricat/goutils/health"
+)
+
+type (
+	DeepseekModelsResponse struct {
+		Object string `json:"object"`
+		Data   []struct {
+			ID      string `json:"id"`
+			Object  string `json:"object"`
+			OwnedBy string `json:"owned_by"`
+		} `json:"data"`
+	}
+)
+
+// ListModels returns the IDs of the models available to the configured provider.
+func (s *Instant) ListModels(ctx context.Context) ([]string, error) {
+	switch s.cfg.Provider {
+	case ProviderOpenAI:
+		if s.openaiClient == nil {
+			return nil, fmt.Errorf("openai client is not configured")
+		}
+		resp, err := s.openaiClient.ListModels(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list openai models: %w", err)
+		}
+		models := make([]string, 0, len(resp.Models))
+		for _, m := range resp.Models {
+			models = append(models, m.ID)
+		}
+		return models, nil
+
+	case ProviderDeepseek:
+		return s.deepseekListModels(ctx)
+	}
+
+	return nil, fmt.Errorf("provider %s not supported for listing models", s.cfg.Provider)
+}
+
+// Ping checks that the configured provider is reachable. openai and deepseek
+// list their models, the other providers are sent a minimal chat request.
+func (s *Instant) Ping(ctx context.Context) error {
+	switch s.cfg.Provider {
+	case ProviderOpenAI, ProviderDeepseek:
+		_, err := s.ListModels(ctx)
+		return err
+	}
+
+	messages := []GeneralChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "ping"}}
+	if _, err := s.rawRequestWithProvider(ctx, s.cfg.Provider, messages, nil); err != nil {
+		return fmt.Errorf("failed to ping %s: %w", s.cfg.Provider, err)
+	}
+	return nil
+}
+
+// HealthChecker returns a health.Checker named "ai" backed by Ping.
+func (s *Instant) HealthChecker() health.Checker {
+	return health.NewChecker("ai", s.Ping)
+}
+
+func (s *Instant) deepseekListModels(ctx context.Context) ([]string, error) {
+	var body DeepseekModelsResponse
+	if err := s.doJSON(ctx, http.MethodGet, s.cfg.DeepseekEndpoint, "/models", s.deepseekHeaders(), nil, &body); err != nil {
+		return nil, fmt.Errorf("failed to list deepseek models: %w", err)
+	}
+
+	models := make([]string, 0, len(body.Data))
+	for _, m := range body.Data {
+		models = append(models, m.ID)
+	}
+	return models, nil
+}
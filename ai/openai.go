@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"time"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -13,16 +14,53 @@ import (
 type (
 	OpenAIRawRequestOptions struct {
 		UseJSON bool
+		// MaxTokens limits the length of the output, the model's default is used when 0
+		MaxTokens int
+		// Temperature and TopP use the API defaults when nil
+		Temperature *float32
+		TopP        *float32
+		Stop        []string
 	}
 )
 
+// openaiOptionsFromParams reads the openai options from the RawRequestWithParams params:
+// format, max_tokens, temperature, top_p and stop.
+func openaiOptionsFromParams(params map[string]any) *OpenAIRawRequestOptions {
+	opts := &OpenAIRawRequestOptions{}
+	if val, ok := params["format"]; ok && val == "json" {
+		opts.UseJSON = true
+	}
+	if val, ok := intParam(params, "max_tokens"); ok {
+		opts.MaxTokens = val
+	}
+	if val, ok := floatParam(params, "temperature"); ok {
+		opts.Temperature = &val
+	}
+	if val, ok := floatParam(params, "top_p"); ok {
+		opts.TopP = &val
+	}
+	switch val := params["stop"].(type) {
+	case string:
+		opts.Stop = []string{val}
+	case []string:
+		opts.Stop = val
+	}
+	return opts
+}
+
 func (s *Instant) OpenAIRawRequest(ctx context.Context, messages []openai.ChatCompletionMessage, opts *OpenAIRawRequestOptions) (string, error) {
+	text, _, err := s.openAIRawRequest(ctx, messages, opts)
+	return text, err
+}
+
+func (s *Instant) openAIRawRequest(ctx context.Context, messages []openai.ChatCompletionMessage, opts *OpenAIRawRequestOptions) (string, Usage, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
 	resultChan := make(chan struct {
-		resp string
-		err  error
+		resp  string
+		usage Usage
+		err   error
 	})
 
 	go func() {
@@ -37,29 +75,43 @@ func (s *Instant) OpenAIRawRequest(ctx context.Context, messages []openai.ChatCo
 					Type: "json_object",
 				}
 			}
+			payload.MaxTokens = opts.MaxTokens
+			payload.Stop = opts.Stop
+			if opts.Temperature != nil {
+				// go-openai omits a zero temperature, send the smallest non-zero value instead
+				payload.Temperature = max(*opts.Temperature, math.SmallestNonzeroFloat32)
+			}
+			if opts.TopP != nil {
+				payload.TopP = *opts.TopP
+			}
 		}
 
+		applyReasoningConstraints(&payload)
+
 		resp, err := s.openaiClient.CreateChatCompletion(ctx, payload)
 		if err != nil {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: err}
 			return
 		}
 
 		if len(resp.Choices) == 0 {
 			resultChan <- struct {
-				resp string
-				err  error
+				resp  string
+				usage Usage
+				err   error
 			}{resp: "", err: nil}
 			return
 		}
 
 		resultChan <- struct {
-			resp string
-			err  error
-		}{resp: resp.Choices[0].Message.Content, err: nil}
+			resp  string
+			usage Usage
+			err   error
+		}{resp: resp.Choices[0].Message.Content, usage: usageFromOpenAI(resp.Usage), err: nil}
 	}()
 
 	select {
@@ -67,19 +119,19 @@ func (s *Instant) OpenAIRawRequest(ctx context.Context, messages []openai.ChatCo
 		// Context was canceled or timed out
 		if errors.Is(ctx.Err(), context.Canceled) {
 			slog.Error("[goutils.ai] OpenAI Request canceled", "error", ctx.Err())
-			return "", fmt.Errorf("request canceled: %w", ctx.Err())
+			return "", Usage{}, fmt.Errorf("request canceled: %w", ctx.Err())
 		}
-		return "", fmt.Errorf("request failed: %w", ctx.Err())
+		return "", Usage{}, fmt.Errorf("request failed: %w", ctx.Err())
 	case result := <-resultChan:
 		if result.err != nil {
 			if errors.Is(result.err, context.Canceled) {
 				slog.Error("[goutils.ai] OpenAI Request canceled", "error", result.err)
-				return "", fmt.Errorf("request canceled: %w", result.err)
+				return "", Usage{}, fmt.Errorf("request canceled: %w", result.err)
 			}
 			slog.Error("[goutils.ai] OpenAI Request error", "error", result.err)
-			return "", result.err
+			return "", Usage{}, result.err
 		}
-		return result.resp, nil
+		return result.resp, result.usage, nil
 	}
 }
 
@@ -88,8 +140,9 @@ func (s *Instant) CreateEmbeddingOpenAI(ctx context.Context, input []string) ([]
 	defer cancel()
 
 	resp, err := s.openaiClient.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-		Input: input,
-		Model: openai.EmbeddingModel(s.cfg.OpenAIEmbeddingModel),
+		Input:      input,
+		Model:      openai.EmbeddingModel(s.cfg.OpenAIEmbeddingModel),
+		Dimensions: s.cfg.OpenAIEmbeddingDimensions,
 	})
 	if err != nil {
 		slog.Error("[goutils.ai] CreateEmbeddingOpenAI error", "error", err)
@@ -0,0 +1,163 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const openaiAPIBase = "https://api.openai.com/v1"
+
+type (
+	OpenAIResponsesContent struct {
+		Type     string `json:"type"`
+		Text     string `json:"text,omitempty"`
+		ImageURL string `json:"image_url,omitempty"`
+	}
+
+	OpenAIResponsesInputItem struct {
+		Role    string                   `json:"role"`
+		Content []OpenAIResponsesContent `json:"content"`
+	}
+
+	OpenAIResponsesTextFormat struct {
+		Type string `json:"type"`
+	}
+
+	OpenAIResponsesText struct {
+		Format OpenAIResponsesTextFormat `json:"format"`
+	}
+
+	OpenAIResponsesRequest struct {
+		Model string                     `json:"model"`
+		Input []OpenAIResponsesInputItem `json:"input"`
+		Text  *OpenAIResponsesText       `json:"text,omitempty"`
+
+		MaxOutputTokens int      `json:"max_output_tokens,omitempty"`
+		Temperature     *float32 `json:"temperature,omitempty"`
+		TopP            *float32 `json:"top_p,omitempty"`
+	}
+
+	OpenAIResponsesOutputItem struct {
+		Type    string                   `json:"type"`
+		Role    string                   `json:"role"`
+		Content []OpenAIResponsesContent `json:"content"`
+	}
+
+	OpenAIResponsesResponse struct {
+		ID     string                      `json:"id"`
+		Status string                      `json:"status"`
+		Model  string                      `json:"model"`
+		Output []OpenAIResponsesOutputItem `json:"output"`
+		Error  *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+			TotalTokens  int `json:"total_tokens"`
+
+			InputTokensDetails struct {
+				CachedTokens int `json:"cached_tokens"`
+			} `json:"input_tokens_details"`
+		} `json:"usage"`
+	}
+)
+
+// OpenAIResponsesRawRequest sends the messages to the OpenAI Responses API and
+// returns the text of the output messages.
+func (s *Instant) OpenAIResponsesRawRequest(ctx context.Context, messages []GeneralChatCompletionMessage, opts *OpenAIRawRequestOptions) (string, error) {
+	text, _, err := s.openAIResponsesRawRequest(ctx, messages, opts)
+	return text, err
+}
+
+func (s *Instant) openAIResponsesRawRequest(ctx context.Context, messages []GeneralChatCompletionMessage, opts *OpenAIRawRequestOptions) (string, Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	payload := OpenAIResponsesRequest{
+		Model: s.cfg.OpenAIGptModel,
+		Input: make([]OpenAIResponsesInputItem, 0, len(messages)),
+	}
+	for _, message := range messages {
+		payload.Input = append(payload.Input, OpenAIResponsesInputItem{
+			Role:    message.Role,
+			Content: openaiResponsesContent(message),
+		})
+	}
+	if opts != nil {
+		if opts.UseJSON {
+			payload.Text = &OpenAIResponsesText{Format: OpenAIResponsesTextFormat{Type: "json_object"}}
+		}
+		payload.MaxOutputTokens = opts.MaxTokens
+		// reasoning models reject sampling parameters
+		if !isReasoningModel(payload.Model) {
+			payload.Temperature = opts.Temperature
+			payload.TopP = opts.TopP
+		}
+	}
+
+	headers := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", s.cfg.OpenAIApiKey),
+	}
+	if s.cfg.OpenAIOrgID != "" {
+		headers["OpenAI-Organization"] = s.cfg.OpenAIOrgID
+	}
+	for k, v := range s.cfg.ExtraHeaders {
+		headers[k] = v
+	}
+
+	var body OpenAIResponsesResponse
+	if err := s.doJSON(ctx, http.MethodPost, openaiAPIBase, "/responses", headers, payload, &body); err != nil {
+		slog.Error("[goutils.ai] OpenAI Responses request error", "error", err)
+		return "", Usage{}, err
+	}
+	if body.Error != nil {
+		return "", Usage{}, fmt.Errorf("openai responses error: %s, %s", body.Error.Code, body.Error.Message)
+	}
+
+	var sb strings.Builder
+	for _, item := range body.Output {
+		if item.Type != "message" {
+			continue
+		}
+		for _, content := range item.Content {
+			if content.Type == "output_text" {
+				sb.WriteString(content.Text)
+			}
+		}
+	}
+	usage := Usage{
+		InputTokens:     body.Usage.InputTokens,
+		OutputTokens:    body.Usage.OutputTokens,
+		CacheReadTokens: body.Usage.InputTokensDetails.CachedTokens,
+	}
+	return sb.String(), usage, nil
+}
+
+func openaiResponsesContent(message GeneralChatCompletionMessage) []OpenAIResponsesContent {
+	// assistant messages are previous outputs, the rest are inputs
+	textType := "input_text"
+	if message.Role == openai.ChatMessageRoleAssistant {
+		textType = "output_text"
+	}
+
+	content := make([]OpenAIResponsesContent, 0, len(message.Parts)+1)
+	if message.Content != "" || len(message.Parts) == 0 {
+		content = append(content, OpenAIResponsesContent{Type: textType, Text: message.Content})
+	}
+	for _, part := range message.Parts {
+		if part.Type == MessagePartTypeImage {
+			content = append(content, OpenAIResponsesContent{Type: "input_image", ImageURL: part.dataURL()})
+		} else {
+			content = append(content, OpenAIResponsesContent{Type: textType, Text: part.Text})
+		}
+	}
+	return content
+}
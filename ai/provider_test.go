@@ -0,0 +1,233 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/ai/azopenai"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go/service/bedrockruntime/bedrockruntimeiface"
+	"github.com/sashabaranov/go-openai"
+)
+
+type fakeBedrock struct {
+	bedrockruntimeiface.BedrockRuntimeAPI
+	reply    string
+	body     []byte
+	response []byte
+}
+
+func (f *fakeBedrock) InvokeModelWithContext(ctx aws.Context, input *bedrockruntime.InvokeModelInput, opts ...request.Option) (*bedrockruntime.InvokeModelOutput, error) {
+	f.body = input.Body
+	buf, _ := json.Marshal(map[string]any{
+		"content": []any{map[string]any{"type": "text", "text": f.reply}},
+	})
+	f.response = buf
+	return &bedrockruntime.InvokeModelOutput{Body: buf}, nil
+}
+
+type fakeAzureTransport struct {
+	reply string
+	body  []byte
+}
+
+func (f *fakeAzureTransport) Do(req *http.Request) (*http.Response, error) {
+	f.body, _ = io.ReadAll(req.Body)
+	buf, _ := json.Marshal(chatCompletionReply(f.reply))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(buf)),
+		Request:    req,
+	}, nil
+}
+
+func chatCompletionReply(reply string) map[string]any {
+	return map[string]any{
+		"choices": []any{map[string]any{
+			"index":         0,
+			"finish_reason": "stop",
+			"message":       map[string]any{"role": "assistant", "content": reply},
+		}},
+	}
+}
+
+// newTestInstant returns an Instant whose provider answers every request with
+// reply, and a func returning the body of the last request sent to the provider.
+func newTestInstant(t *testing.T, provider, reply string) (*Instant, func() []byte) {
+	t.Helper()
+
+	switch provider {
+	case ProviderBedrock:
+		fake := &fakeBedrock{reply: reply}
+		s := New(Config{Provider: provider})
+		s.bedrockClient = fake
+		return s, func() []byte { return fake.body }
+
+	case ProviderAzure:
+		fake := &fakeAzureTransport{reply: reply}
+		client, err := azopenai.NewClientWithKeyCredential("https://example.openai.azure.com", azcore.NewKeyCredential("key"), &azopenai.ClientOptions{
+			ClientOptions: azcore.ClientOptions{Transport: fake},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := New(Config{Provider: provider, AzureOpenAIGptDeploymentID: "gpt-4o"})
+		s.azureOpenAIClient = client
+		return s, func() []byte { return fake.body }
+	}
+
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(chatCompletionReply(reply))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := New(Config{
+		Provider:         provider,
+		OpenAIGptModel:   "gpt-4o",
+		DeepseekEndpoint: srv.URL,
+		DeepseekModel:    "deepseek-chat",
+	})
+	if provider == ProviderOpenAI {
+		openaiCfg := openai.DefaultConfig("key")
+		openaiCfg.BaseURL = srv.URL
+		s.openaiClient = openai.NewClientWithConfig(openaiCfg)
+	}
+	return s, func() []byte { return body }
+}
+
+type sentRequest struct {
+	System   string `json:"system"`
+	Messages []struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	} `json:"messages"`
+}
+
+// checkInstruction fails unless the request body carries instruction in the way
+// the provider expects: a top-level system field for bedrock, a system message otherwise.
+func checkInstruction(t *testing.T, provider string, body []byte, instruction string) {
+	t.Helper()
+
+	var req sentRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("%s: invalid request body %q: %v", provider, body, err)
+	}
+
+	var system string
+	for _, m := range req.Messages {
+		if m.Role == ChatMessageRoleSystem {
+			if provider == ProviderBedrock {
+				t.Errorf("%s: system message sent in messages", provider)
+			}
+			system += string(m.Content)
+		}
+	}
+	if provider == ProviderBedrock {
+		system = req.System
+	}
+	if !strings.Contains(system, instruction) {
+		t.Errorf("%s: instruction %q not sent as system prompt, body: %s", provider, instruction, body)
+	}
+}
+
+func TestClassifyRequestBody(t *testing.T) {
+	for _, provider := range []string{ProviderOpenAI, ProviderAzure, ProviderBedrock, ProviderDeepseek} {
+		s, sentBody := newTestInstant(t, provider, `{"label": "spam", "confidence": 0.9}`)
+
+		label, confidence, err := s.Classify(context.Background(), "buy now", []string{"spam", "ham"})
+		if err != nil {
+			t.Errorf("%s: Classify() error: %v", provider, err)
+			continue
+		}
+		if label != "spam" || confidence != 0.9 {
+			t.Errorf("%s: Classify() = %q, %v, want spam, 0.9", provider, label, confidence)
+		}
+
+		body := sentBody()
+		checkInstruction(t, provider, body, "Classify the text")
+		if !bytes.Contains(body, []byte("ham")) {
+			t.Errorf("%s: labels missing from request body: %s", provider, body)
+		}
+	}
+}
+
+func TestMapReduceSummarizeRequestBody(t *testing.T) {
+	for _, provider := range []string{ProviderOpenAI, ProviderAzure, ProviderBedrock, ProviderDeepseek} {
+		s, sentBody := newTestInstant(t, provider, "a summary")
+
+		ret, err := s.MapReduceSummarize(context.Background(), "first part\n\nsecond part", 1000, "Summarize in one sentence.")
+		if err != nil {
+			t.Errorf("%s: MapReduceSummarize() error: %v", provider, err)
+			continue
+		}
+		if ret.Text != "a summary" {
+			t.Errorf("%s: MapReduceSummarize() = %q, want %q", provider, ret.Text, "a summary")
+		}
+		checkInstruction(t, provider, sentBody(), "Summarize in one sentence.")
+	}
+}
+
+func TestBedrockRawResponse(t *testing.T) {
+	fake := &fakeBedrock{reply: "hello"}
+	s := New(Config{Provider: ProviderBedrock, Debug: true})
+	s.bedrockClient = fake
+
+	ret, err := s.RawRequest(context.Background(), []GeneralChatCompletionMessage{
+		{Role: ChatMessageRoleUser, Content: "hi"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(ret.Raw, fake.response) {
+		t.Errorf("Raw = %s, want %s", ret.Raw, fake.response)
+	}
+}
+
+func TestRawResponseOnProviderError(t *testing.T) {
+	const errBody = `{"error":{"message":"model overloaded","type":"server_error"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, errBody)
+	}))
+	defer srv.Close()
+
+	s := New(Config{
+		Provider:         ProviderDeepseek,
+		DeepseekEndpoint: srv.URL,
+		DeepseekModel:    "deepseek-chat",
+		Debug:            true,
+	})
+	ret, err := s.RawRequest(context.Background(), []GeneralChatCompletionMessage{
+		{Role: ChatMessageRoleUser, Content: "hi"},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if ret == nil || string(ret.Raw) != errBody {
+		t.Fatalf("Raw = %v, want %s", ret, errBody)
+	}
+}
+
+func TestPingSendsMinimalRequest(t *testing.T) {
+	s, body := newTestInstant(t, ProviderBedrock, "pong")
+	if err := s.HealthChecker().Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body()), `"ping"`) {
+		t.Fatalf("ping request not sent, body: %s", body())
+	}
+}
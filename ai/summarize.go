@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	defaultSummarizeInstruction = "Summarize the following text concisely, keeping the key facts."
+	combineSummariesInstruction = "The following are summaries of consecutive parts of one document. Combine them into a single summary."
+	// maxReduceRounds bounds how many times the summaries are combined again
+	// when they still don't fit into one chunk.
+	maxReduceRounds = 3
+)
+
+// MapReduceSummarize summarizes text that may exceed the context window: it
+// splits the text into chunks of about chunkTokens tokens, summarizes each chunk
+// following instruction, then combines the chunk summaries into one. The Usage
+// of the result is summed over all requests.
+func (s *Instant) MapReduceSummarize(ctx context.Context, text string, chunkTokens int, instruction string) (*Result, error) {
+	if chunkTokens <= 0 {
+		return nil, fmt.Errorf("chunkTokens must be positive")
+	}
+	if instruction == "" {
+		instruction = defaultSummarizeInstruction
+	}
+
+	model := s.currentModel()
+	count := func(text string) int {
+		return s.countTextTokens(text, model)
+	}
+
+	ret := &Result{}
+	chunks := splitTextByTokens(text, chunkTokens, count)
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("nothing to summarize")
+	}
+
+	summaries, err := s.summarizeChunks(ctx, chunks, instruction, ret)
+	if err != nil {
+		return nil, err
+	}
+
+	for round := 0; len(summaries) > 1; round++ {
+		combined := strings.Join(summaries, "\n\n")
+		if round >= maxReduceRounds || count(combined) <= chunkTokens {
+			// the summaries fit into one request, or give up on shrinking them further
+			summaries, err = s.summarizeChunks(ctx, []string{combined}, combineInstruction(instruction), ret)
+		} else {
+			summaries, err = s.summarizeChunks(ctx, splitTextByTokens(combined, chunkTokens, count), combineInstruction(instruction), ret)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret.Text = summaries[0]
+	return ret, nil
+}
+
+func (s *Instant) summarizeChunks(ctx context.Context, chunks []string, instruction string, ret *Result) ([]string, error) {
+	summaries := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		resp, err := s.RawRequest(ctx, []GeneralChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: instruction},
+			{Role: openai.ChatMessageRoleUser, Content: chunk},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize chunk %d: %w", i, err)
+		}
+		ret.Provider = resp.Provider
+		ret.Usage.Add(resp.Usage)
+		ret.StepUsages = append(ret.StepUsages, resp.Usage)
+		summaries = append(summaries, strings.TrimSpace(resp.Text))
+	}
+	return summaries, nil
+}
+
+func combineInstruction(instruction string) string {
+	return combineSummariesInstruction + "\n\n" + instruction
+}
+
+// splitTextByTokens splits text into chunks of at most maxTokens tokens, breaking
+// at paragraphs where possible and at words for paragraphs that are too long.
+func splitTextByTokens(text string, maxTokens int, count func(string) int) []string {
+	chunks := make([]string, 0)
+	var current []string
+	currentTokens := 0
+
+	flush := func() {
+		if len(current) != 0 {
+			chunks = append(chunks, strings.Join(current, "\n\n"))
+			current = nil
+			currentTokens = 0
+		}
+	}
+	add := func(piece string) {
+		tokens := count(piece)
+		if len(current) != 0 && currentTokens+tokens > maxTokens {
+			flush()
+		}
+		current = append(current, piece)
+		currentTokens += tokens
+	}
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if count(paragraph) <= maxTokens {
+			add(paragraph)
+			continue
+		}
+
+		// the paragraph alone is too long, split it at words.
+		// counting word by word overestimates a bit, which is fine for a budget.
+		flush()
+		var words []string
+		wordsTokens := 0
+		for _, word := range strings.Fields(paragraph) {
+			tokens := count(word + " ")
+			if len(words) != 0 && wordsTokens+tokens > maxTokens {
+				chunks = append(chunks, strings.Join(words, " "))
+				words = nil
+				wordsTokens = 0
+			}
+			words = append(words, word)
+			wordsTokens += tokens
+		}
+		if len(words) != 0 {
+			chunks = append(chunks, strings.Join(words, " "))
+		}
+	}
+	flush()
+	return chunks
+}
@@ -1,13 +1,12 @@
 package ai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -15,6 +14,13 @@ type (
 	SusanoParams struct {
 		Format     string                 `json:"format"`
 		Conditions SusanoParamsConditions `json:"conditions"`
+		// Search enables susanoo's web search, it's omitted when nil
+		Search *SusanoParamsSearch `json:"search,omitempty"`
+	}
+
+	SusanoParamsSearch struct {
+		Enabled bool `json:"enabled"`
+		Limit   int  `json:"limit"`
 	}
 
 	SusanoParamsConditions struct {
@@ -52,47 +58,110 @@ type (
 )
 
 func (s *Instant) SusanooRawRequest(ctx context.Context, messages []GeneralChatCompletionMessage, params map[string]any) (*SusanooTaskResultResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Minute*3)
+	task := &SusanooTaskRequest{
+		Messages: messages,
+		Params:   params,
+	}
+	if task.Params == nil {
+		task.Params = make(map[string]any)
+	}
+	return s.susanooRunTask(ctx, task)
+}
+
+func (s *Instant) SusanooCreateEmbedding(ctx context.Context, input []string) ([]float32, error) {
+	task := &SusanooTaskRequest{
+		Messages: []GeneralChatCompletionMessage{},
+		Params: map[string]any{
+			"type":  "embedding",
+			"input": input,
+		},
+	}
+	resp, err := s.susanooRunTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := resp.Data.Result["embedding"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("no embedding in susanoo task result: %s", resp.Data.TraceID)
+	}
+
+	vec := make([]float32, 0, len(raw))
+	for _, v := range raw {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid embedding value in susanoo task result: %v", v)
+		}
+		vec = append(vec, float32(f))
+	}
+	return vec, nil
+}
+
+func (s *Instant) susanooRunTask(ctx context.Context, task *SusanooTaskRequest) (*SusanooTaskResultResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.SusanooTimeout)
 	defer cancel()
 
 	resultChan := make(chan struct {
 		result *SusanooTaskResultResponse
 		err    error
-	})
+	}, 1)
 
-	go func() error {
-		task := &SusanooTaskRequest{
-			Messages: messages,
-			Params:   params,
-		}
-		if task.Params == nil {
-			task.Params = make(map[string]any)
+	go func() {
+		sendResult := func(result *SusanooTaskResultResponse, err error) {
+			resultChan <- struct {
+				result *SusanooTaskResultResponse
+				err    error
+			}{result: result, err: err}
 		}
 
 		traceID, err := s.SusanooCreateTask(ctx, task)
 		if err != nil {
-			return err
+			sendResult(nil, err)
+			return
 		}
 
+		interval := s.cfg.SusanooPollInterval
 		for {
 			result, err := s.SusanooFetchTaskResult(ctx, traceID)
 			if err != nil {
-				return err
-			}
-			if result.Data.Status == 1 || result.Data.Status == 2 {
-				// 1, assigned, but not started
-				// 2, in progress
-				time.Sleep(time.Second * 3)
-				continue
+				sendResult(nil, err)
+				return
 			}
-			if result.Data.Status == 3 || result.Data.Status == 4 {
+			if result.Data.Status == 3 {
 				// 3, finished
+				sendResult(result, nil)
+				return
+			}
+			if result.Data.Status == 4 {
 				// 4, failed
-				resultChan <- struct {
-					result *SusanooTaskResultResponse
-					err    error
-				}{result: result, err: nil}
-				return nil
+				sendResult(nil, susanooTaskError(result))
+				return
+			}
+
+			// 1, assigned, but not started
+			// 2, in progress
+			wait := interval
+			if deadline, ok := ctx.Deadline(); ok {
+				remaining := time.Until(deadline)
+				if remaining <= 0 {
+					sendResult(nil, ctx.Err())
+					return
+				}
+				if wait > remaining {
+					wait = remaining
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				sendResult(nil, ctx.Err())
+				return
+			case <-time.After(wait):
+			}
+
+			interval *= 2
+			if interval > s.cfg.SusanooPollMaxInterval {
+				interval = s.cfg.SusanooPollMaxInterval
 			}
 		}
 	}()
@@ -122,55 +191,23 @@ func (s *Instant) SusanooCreateTask(ctx context.Context, task *SusanooTaskReques
 	ctx, cancel := context.WithTimeout(ctx, time.Second*60)
 	defer cancel()
 
+	// buffered so the goroutine can exit when ctx is done first
 	resultChan := make(chan struct {
 		traceID string
 		err     error
-	})
-
-	go func() error {
-		payload, err := json.Marshal(task)
-		if err != nil {
-			return err
-		}
-
-		buf := bytes.NewBuffer(payload)
-		apiUrl := fmt.Sprintf("%s/tasks", s.cfg.SusanooEndpoint)
-		req, err := http.NewRequest(http.MethodPost, apiUrl, buf)
-		if err != nil {
-			return err
-		}
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("X-SUSANOO-KEY", s.cfg.SusanooApiKey)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+	}, 1)
 
+	go func() {
 		var body SusanooTaskResponse
-		if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return err
-		}
-
-		if body.Data.Code != 0 {
-			return fmt.Errorf("failed to create task at susanoo: %d", body.Data.Code)
-		}
-
-		if err != nil {
-			resultChan <- struct {
-				traceID string
-				err     error
-			}{traceID: "", err: err}
-			return err
+		err := s.doJSON(ctx, http.MethodPost, s.cfg.SusanooEndpoint, "/tasks", s.susanooHeaders(), task, &body)
+		if err == nil && body.Data.Code != 0 {
+			err = fmt.Errorf("failed to create task at susanoo: %d", body.Data.Code)
 		}
 
 		resultChan <- struct {
 			traceID string
 			err     error
-		}{traceID: body.Data.TraceID, err: nil}
-
-		return nil
+		}{traceID: body.Data.TraceID, err: err}
 	}()
 
 	select {
@@ -195,22 +232,9 @@ func (s *Instant) SusanooCreateTask(ctx context.Context, task *SusanooTaskReques
 }
 
 func (s *Instant) SusanooFetchTaskResult(ctx context.Context, traceID string) (*SusanooTaskResultResponse, error) {
-	apiUrl := fmt.Sprintf("%s/tasks/result?trace_id=%s", s.cfg.SusanooEndpoint, traceID)
-	req, err := http.NewRequest(http.MethodGet, apiUrl, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("X-SUSANOO-KEY", s.cfg.SusanooApiKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var body SusanooTaskResultResponse
-	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+	path := "/tasks/result?trace_id=" + url.QueryEscape(traceID)
+	if err := s.doJSON(ctx, http.MethodGet, s.cfg.SusanooEndpoint, path, s.susanooHeaders(), nil, &body); err != nil {
 		return nil, err
 	}
 
@@ -221,11 +245,41 @@ func (s *Instant) SusanooFetchTaskResult(ctx context.Context, traceID string) (*
 	return &body, nil
 }
 
+func (s *Instant) susanooHeaders() map[string]string {
+	return map[string]string{
+		"X-SUSANOO-KEY": s.cfg.SusanooApiKey,
+	}
+}
+
+func susanooTaskError(result *SusanooTaskResultResponse) error {
+	for _, key := range []string{"error", "err", "message"} {
+		if val, ok := result.Data.Result[key]; ok && val != nil && val != "" {
+			return fmt.Errorf("susanoo task %s failed: %v", result.Data.TraceID, val)
+		}
+	}
+	return fmt.Errorf("susanoo task %s failed", result.Data.TraceID)
+}
+
 func (p *SusanoParams) ToMap() map[string]any {
 	params := make(map[string]any)
 	params["format"] = p.Format
 	params["conditions"] = make(map[string]any)
 	params["conditions"].(map[string]any)["preferred_provider"] = p.Conditions.PreferredProvider
 	params["conditions"].(map[string]any)["preferred_model"] = p.Conditions.PreferredModel
+	if p.Search != nil {
+		params["search"] = map[string]any{
+			"enabled": p.Search.Enabled,
+			"limit":   p.Search.Limit,
+		}
+	}
 	return params
 }
+
+// RawRequestWithSusanoParams is RawRequestWithParams with the params built from p.
+func (s *Instant) RawRequestWithSusanoParams(ctx context.Context, messages []GeneralChatCompletionMessage, p *SusanoParams) (*Result, error) {
+	var params map[string]any
+	if p != nil {
+		params = p.ToMap()
+	}
+	return s.RawRequestWithParams(ctx, messages, params)
+}
@@ -0,0 +1,135 @@
+package ai
+
+import (
+	"log/slog"
+	"unicode"
+)
+
+const (
+	// per-message overhead for role and separators
+	messageTokenOverhead = 4
+	// rough tokens of an image part, the real cost depends on the resolution and provider
+	imageTokenEstimate = 1000
+)
+
+// estimateTextTokens roughly counts tokens in text: ~4 latin characters per
+// token, one token per CJK character.
+func estimateTextTokens(text string) int {
+	tokens := 0
+	latin := 0
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			tokens++
+			continue
+		}
+		latin++
+	}
+	tokens += (latin + 3) / 4
+	return tokens
+}
+
+// EstimateTokens estimates the input tokens of messages before sending them.
+// It uses cfg.TokenCounter when set, otherwise a rough approximation.
+// If model is empty, the model configured for the current provider is used.
+func (s *Instant) EstimateTokens(messages []GeneralChatCompletionMessage, model string) int {
+	if model == "" {
+		model = s.currentModel()
+	}
+	total := 0
+	for _, message := range messages {
+		total += s.estimateMessageTokens(message, model)
+	}
+	return total
+}
+
+func (s *Instant) estimateMessageTokens(message GeneralChatCompletionMessage, model string) int {
+	tokens := s.countTextTokens(message.Content, model) + messageTokenOverhead
+	for _, part := range message.Parts {
+		if part.Type == MessagePartTypeImage {
+			tokens += imageTokenEstimate
+		} else {
+			tokens += s.countTextTokens(part.Text, model)
+		}
+	}
+	return tokens
+}
+
+func (s *Instant) countTextTokens(text, model string) int {
+	if s.cfg.TokenCounter != nil {
+		return s.cfg.TokenCounter(text, model)
+	}
+	return estimateTextTokens(text)
+}
+
+func (s *Instant) currentModel() string {
+	switch s.cfg.Provider {
+	case ProviderOpenAI:
+		return s.cfg.OpenAIGptModel
+	case ProviderAzure:
+		return s.cfg.AzureOpenAIGptDeploymentID
+	case ProviderBedrock:
+		return s.cfg.AwsBedrockModelArn
+	case ProviderDeepseek:
+		return s.cfg.DeepseekModel
+	}
+	return ""
+}
+
+// trimMessages drops the oldest non-system messages until the conversation fits
+// into maxTokens. System messages and the latest message are always kept, and
+// whole turns are dropped so the remaining conversation starts with a user message.
+func trimMessages(messages []GeneralChatCompletionMessage, maxTokens int, count func(GeneralChatCompletionMessage) int) []GeneralChatCompletionMessage {
+	if maxTokens <= 0 || len(messages) == 0 {
+		return messages
+	}
+
+	total := 0
+	for _, message := range messages {
+		total += count(message)
+	}
+	if total <= maxTokens {
+		return messages
+	}
+
+	last := len(messages) - 1
+	dropped := make([]bool, len(messages))
+	for i := 0; i < last; i++ {
+		if messages[i].Role == "system" {
+			continue
+		}
+		// stop at the start of a turn once the conversation fits
+		if total <= maxTokens && messages[i].Role == "user" {
+			break
+		}
+		dropped[i] = true
+		total -= count(messages[i])
+	}
+
+	trimmed := make([]GeneralChatCompletionMessage, 0, len(messages))
+	for i, message := range messages {
+		if !dropped[i] {
+			trimmed = append(trimmed, message)
+		}
+	}
+
+	if total > maxTokens {
+		slog.Warn("[goutils.ai] messages still exceed token budget after trimming", "tokens", total, "max", maxTokens)
+	}
+	return trimmed
+}
+
+func intParam(params map[string]any, key string) (int, bool) {
+	val, ok := params[key]
+	if !ok {
+		return 0, false
+	}
+	switch v := val.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,115 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrimMessages(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+	messages := []GeneralChatCompletionMessage{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: long},
+		{Role: "assistant", Content: long},
+		{Role: "user", Content: "What did I say?"},
+	}
+
+	s := New(Config{})
+	count := func(m GeneralChatCompletionMessage) int {
+		return s.estimateMessageTokens(m, "")
+	}
+
+	trimmed := trimMessages(messages, 50, count)
+	if len(trimmed) != 2 {
+		t.Fatalf("trimMessages() kept %d messages, want 2", len(trimmed))
+	}
+	if trimmed[0].Role != "system" {
+		t.Errorf("first message role = %s, want system", trimmed[0].Role)
+	}
+	if trimmed[1].Content != "What did I say?" {
+		t.Errorf("last message = %q, want the latest message", trimmed[1].Content)
+	}
+
+	if got := trimMessages(messages, 0, count); len(got) != len(messages) {
+		t.Errorf("trimMessages() with no budget kept %d messages, want %d", len(got), len(messages))
+	}
+}
+
+func TestEstimateTokensWithCounter(t *testing.T) {
+	s := New(Config{
+		TokenCounter: func(text, model string) int {
+			return len(strings.Fields(text))
+		},
+	})
+	messages := []GeneralChatCompletionMessage{
+		{Role: "user", Content: "one two three"},
+	}
+	if got := s.EstimateTokens(messages, "gpt-4o"); got != 3+messageTokenOverhead {
+		t.Errorf("EstimateTokens() = %d, want %d", got, 3+messageTokenOverhead)
+	}
+}
+
+func TestSplitTextByTokens(t *testing.T) {
+	count := func(text string) int { return len(strings.Fields(text)) }
+
+	text := "one two three\n\nfour five\n\n\n\nsix seven eight nine ten eleven"
+	chunks := splitTextByTokens(text, 5, count)
+	want := []string{"one two three\n\nfour five", "six seven eight nine ten", "eleven"}
+	if len(chunks) != len(want) {
+		t.Fatalf("splitTextByTokens() = %q, want %q", chunks, want)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Errorf("chunk %d = %q, want %q", i, chunks[i], want[i])
+		}
+	}
+
+	if got := splitTextByTokens("  \n\n ", 5, count); len(got) != 0 {
+		t.Errorf("splitTextByTokens() of blank text = %q, want none", got)
+	}
+}
+
+func TestTrimMessagesKeepsWholeTurns(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+	messages := []GeneralChatCompletionMessage{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: long},
+		{Role: "assistant", Content: "OK"},
+		{Role: "user", Content: "Next question"},
+		{Role: "assistant", Content: "Sure"},
+		{Role: "user", Content: "What did I say?"},
+	}
+
+	s := New(Config{})
+	count := func(m GeneralChatCompletionMessage) int {
+		return s.estimateMessageTokens(m, "")
+	}
+
+	// dropping the long user message is enough to fit, its reply must go too
+	trimmed := trimMessages(messages, 60, count)
+	if len(trimmed) != 4 {
+		t.Fatalf("trimMessages() kept %d messages, want 4", len(trimmed))
+	}
+	if trimmed[0].Role != "system" || trimmed[1].Role != "user" || trimmed[1].Content != "Next question" {
+		t.Errorf("trimMessages() = %v, want the conversation to restart at a user turn", trimmed)
+	}
+}
+
+func TestEstimateTokensCountsParts(t *testing.T) {
+	s := New(Config{})
+	text := GeneralChatCompletionMessage{Role: "user", Content: "describe"}
+	withImage := GeneralChatCompletionMessage{
+		Role:    "user",
+		Content: "describe",
+		Parts: []GeneralChatCompletionMessagePart{
+			{Type: MessagePartTypeImage, ImageURL: "https://example.com/a.png"},
+			{Type: MessagePartTypeText, Text: strings.Repeat("word ", 40)},
+		},
+	}
+
+	got := s.EstimateTokens([]GeneralChatCompletionMessage{withImage}, "")
+	want := s.EstimateTokens([]GeneralChatCompletionMessage{text}, "") + imageTokenEstimate + estimateTextTokens(strings.Repeat("word ", 40))
+	if got != want {
+		t.Errorf("EstimateTokens() = %d, want %d", got, want)
+	}
+}
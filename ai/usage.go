@@ -0,0 +1,63 @@
+package ai
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/ai/azopenai"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+type (
+	// Usage is the token usage of one or more requests.
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+		// CacheReadTokens are input tokens served from the prompt cache
+		CacheReadTokens int `json:"cache_read_tokens"`
+		// CacheWriteTokens are input tokens written to the prompt cache
+		CacheWriteTokens int `json:"cache_write_tokens"`
+	}
+)
+
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CacheWriteTokens += other.CacheWriteTokens
+}
+
+func (u Usage) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+func usageFromOpenAI(u openai.Usage) Usage {
+	usage := Usage{
+		InputTokens:  u.PromptTokens,
+		OutputTokens: u.CompletionTokens,
+	}
+	if u.PromptTokensDetails != nil {
+		usage.CacheReadTokens = u.PromptTokensDetails.CachedTokens
+	}
+	return usage
+}
+
+func usageFromAzure(u *azopenai.CompletionsUsage) Usage {
+	usage := Usage{}
+	if u == nil {
+		return usage
+	}
+	if u.PromptTokens != nil {
+		usage.InputTokens = int(*u.PromptTokens)
+	}
+	if u.CompletionTokens != nil {
+		usage.OutputTokens = int(*u.CompletionTokens)
+	}
+	return usage
+}
+
+func usageFromBedrock(u map[string]int) Usage {
+	return Usage{
+		InputTokens:      u["input_tokens"],
+		OutputTokens:     u["output_tokens"],
+		CacheReadTokens:  u["cache_read_input_tokens"],
+		CacheWriteTokens: u["cache_creation_input_tokens"],
+	}
+}
@@ -1,7 +1,207 @@
 package ai
 
-import "strings"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
 
 func supportJSONResponse(model string) bool {
 	return strings.HasPrefix(model, "gpt-4") || strings.HasPrefix(model, "gpt-3.5") || strings.HasPrefix(model, "deepseek-chat")
 }
+
+// ExtractCodeBlock returns the content of the first fenced code block in input
+// whose language tag is lang, or of the first fenced block when lang is empty.
+// A block without a closing fence runs to the end of input.
+func ExtractCodeBlock(input, lang string) (string, bool) {
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		tag, ok := strings.CutPrefix(strings.TrimSpace(line), "```")
+		if !ok {
+			continue
+		}
+		if lang != "" && !strings.EqualFold(strings.TrimSpace(tag), lang) {
+			continue
+		}
+
+		content := make([]string, 0)
+		for _, line := range lines[i+1:] {
+			if strings.TrimSpace(line) == "```" {
+				break
+			}
+			content = append(content, line)
+		}
+		return strings.Join(content, "\n"), true
+	}
+	return "", false
+}
+
+var thinkBlockRe = regexp.MustCompile(`(?s)<(think|thinking|reasoning)>.*?</(think|thinking|reasoning)>`)
+
+// StripThinkTags removes the <think>...</think> reasoning blocks that models like
+// deepseek-r1 put before the answer. Text before a dangling closing tag is removed too.
+func StripThinkTags(text string) string {
+	text = thinkBlockRe.ReplaceAllString(text, "")
+	for _, tag := range []string{"</think>", "</thinking>", "</reasoning>"} {
+		if idx := strings.LastIndex(text, tag); idx >= 0 {
+			text = text[idx+len(tag):]
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+// isReasoningModel reports whether model is an OpenAI reasoning model (o1, o3, o4...),
+// which rejects temperature and max_tokens.
+func isReasoningModel(model string) bool {
+	for _, prefix := range []string{"o1", "o3", "o4"} {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// applyReasoningConstraints adjusts an OpenAI chat request for reasoning models:
+// max_tokens becomes max_completion_tokens, sampling parameters are dropped and,
+// for the legacy o1-mini and o1-preview models, system messages are sent as user messages.
+func applyReasoningConstraints(req *openai.ChatCompletionRequest) {
+	if !isReasoningModel(req.Model) {
+		return
+	}
+	if req.MaxTokens != 0 {
+		if req.MaxCompletionTokens == 0 {
+			req.MaxCompletionTokens = req.MaxTokens
+		}
+		req.MaxTokens = 0
+	}
+	req.Temperature = 0
+	req.TopP = 0
+	req.PresencePenalty = 0
+	req.FrequencyPenalty = 0
+
+	if strings.HasPrefix(req.Model, "o1-mini") || strings.HasPrefix(req.Model, "o1-preview") {
+		messages := make([]openai.ChatCompletionMessage, len(req.Messages))
+		for i, m := range req.Messages {
+			if m.Role == openai.ChatMessageRoleSystem {
+				m.Role = openai.ChatMessageRoleUser
+			}
+			messages[i] = m
+		}
+		req.Messages = messages
+	}
+}
+
+// headerTransport adds fixed headers to every outgoing request.
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// APIError is returned by doJSON when a provider responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Code       string
+	Message    string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("api error %d: %s (type: %s, code: %s)", e.StatusCode, e.Message, e.Type, e.Code)
+	}
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Body)
+}
+
+// doJSON sends in as a JSON body to base+path and decodes a successful response into out.
+// Error responses are decoded from the common {"error": {...}} envelope into an *APIError.
+func (s *Instant) doJSON(ctx context.Context, method, base, path string, headers map[string]string, in, out any) error {
+	var reqBody io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		reqBody = bytes.NewBuffer(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, base+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+		var envelope struct {
+			Error struct {
+				Message string `json:"message"`
+				Type    string `json:"type"`
+				Code    any    `json:"code"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &envelope) == nil {
+			apiErr.Message = envelope.Error.Message
+			apiErr.Type = envelope.Error.Type
+			if envelope.Error.Code != nil {
+				apiErr.Code = fmt.Sprint(envelope.Error.Code)
+			}
+		}
+		return apiErr
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+func floatParam(params map[string]any, key string) (float32, bool) {
+	val, ok := params[key]
+	if !ok {
+		return 0, false
+	}
+	switch v := val.(type) {
+	case float32:
+		return v, true
+	case float64:
+		return float32(v), true
+	case int:
+		return float32(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,42 @@
+package ai
+
+import "testing"
+
+func TestStripThinkTags(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"hello", "hello"},
+		{"<think>\nlet me see\n</think>\n\nthe answer", "the answer"},
+		{"<thinking>a</thinking>b<think>c</think>d", "bd"},
+		{"reasoning without opening tag</think>answer", "answer"},
+	}
+	for _, c := range cases {
+		if got := StripThinkTags(c.input); got != c.want {
+			t.Errorf("StripThinkTags(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
+
+func TestExtractCodeBlock(t *testing.T) {
+	cases := []struct {
+		input string
+		lang  string
+		want  string
+		found bool
+	}{
+		{"text\n```json\n{\"a\": 1}\n```\nmore", "json", "{\"a\": 1}", true},
+		{"```\nplain\n```", "", "plain", true},
+		{"```yaml\na: 1\n```\n```json\n{}\n```", "json", "{}", true},
+		{"```JSON\n{\"a\": 1}", "json", "{\"a\": 1}", true},
+		{"```yaml\na: 1\n```", "json", "", false},
+		{"no fences", "", "", false},
+	}
+	for _, c := range cases {
+		got, found := ExtractCodeBlock(c.input, c.lang)
+		if got != c.want || found != c.found {
+			t.Errorf("ExtractCodeBlock(%q, %q) = %q, %v, want %q, %v", c.input, c.lang, got, found, c.want, c.found)
+		}
+	}
+}
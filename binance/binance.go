@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/shopspring/decimal"
 )
 
@@ -26,14 +27,41 @@ type Binance struct {
 	APIKey    string
 	SecretKey string
 	client    *http.Client
+	// dialer is used for the websocket streams, it follows the client's transport
+	dialer *websocket.Dialer
 }
 
-func New(apiKey, secretKey string) *Binance {
+// New creates a Binance client. An optional http.Client can be passed to
+// route requests through a proxy or set a custom timeout.
+func New(apiKey, secretKey string, httpClient ...*http.Client) *Binance {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if len(httpClient) > 0 && httpClient[0] != nil {
+		client = httpClient[0]
+	}
 	return &Binance{
 		APIKey:    apiKey,
 		SecretKey: secretKey,
-		client:    &http.Client{},
+		client:    client,
+		dialer:    newDialer(client),
+	}
+}
+
+// newDialer builds a websocket dialer with the proxy, TLS config and dial
+// function of the client's transport.
+func newDialer(client *http.Client) *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	transport, ok := client.Transport.(*http.Transport)
+	if client.Transport == nil {
+		transport, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if ok {
+		dialer.Proxy = transport.Proxy
+		dialer.NetDialContext = transport.DialContext
+		if transport.TLSClientConfig != nil {
+			dialer.TLSClientConfig = transport.TLSClientConfig.Clone()
+		}
 	}
+	return &dialer
 }
 
 func (c *Binance) QuerySpotOrders(ctx context.Context, symbol string) (string, error) {
@@ -113,6 +141,80 @@ func (c *Binance) GetTradesByOrderID(ctx context.Context, symbol, orderID string
 	return trades, nil
 }
 
+// GetMyTrades retrieves the account trades of a symbol between startTime and endTime.
+// The API requires a symbol and limits a query to 24 hours, so the range is split
+// into 24 hour windows and each window is paged with limit (up to 1000) trades per request.
+func (c *Binance) GetMyTrades(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*Trade, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+
+	var trades []*Trade
+	seen := make(map[int64]struct{})
+	for windowStart := startTime; windowStart.Before(endTime); windowStart = windowStart.Add(24 * time.Hour) {
+		windowEnd := windowStart.Add(24*time.Hour - time.Millisecond)
+		if windowEnd.After(endTime) {
+			windowEnd = endTime
+		}
+
+		pageStart := windowStart
+		for {
+			params := url.Values{
+				"symbol":    {symbol},
+				"startTime": {strconv.FormatInt(pageStart.UnixMilli(), 10)},
+				"endTime":   {strconv.FormatInt(windowEnd.UnixMilli(), 10)},
+				"limit":     {strconv.Itoa(limit)},
+			}
+			responseData, err := c.request(ctx, "GET", "/api/v3/myTrades", params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get trades: %w", err)
+			}
+
+			var page []*Trade
+			if err := json.Unmarshal(responseData, &page); err != nil {
+				return nil, fmt.Errorf("failed to parse trades: %w", err)
+			}
+
+			for _, t := range page {
+				if _, ok := seen[t.ID]; ok {
+					continue
+				}
+				seen[t.ID] = struct{}{}
+				t.Formalize()
+				trades = append(trades, t)
+			}
+
+			if len(page) < limit {
+				break
+			}
+			// continue from the last trade, trades in the same millisecond are deduplicated by ID
+			next := time.UnixMilli(int64(page[len(page)-1].UnixTime))
+			if !next.After(pageStart) {
+				next = pageStart.Add(time.Millisecond)
+			}
+			if next.After(windowEnd) {
+				break
+			}
+			pageStart = next
+		}
+	}
+
+	return trades, nil
+}
+
+// GetMyTradesForSymbols calls GetMyTrades for each symbol and returns all trades.
+func (c *Binance) GetMyTradesForSymbols(ctx context.Context, symbols []string, startTime, endTime time.Time, limit int) ([]*Trade, error) {
+	var trades []*Trade
+	for _, symbol := range symbols {
+		symbolTrades, err := c.GetMyTrades(ctx, symbol, startTime, endTime, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trades of %s: %w", symbol, err)
+		}
+		trades = append(trades, symbolTrades...)
+	}
+	return trades, nil
+}
+
 type PutSpotOrderParams struct {
 	NewClientOrderID string
 	Symbol           string
@@ -202,7 +304,7 @@ func (c *Binance) CancelAllSpotOrders(ctx context.Context, symbol string) ([]*Or
 	return orders, nil
 }
 
-func get(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+func get(ctx context.Context, client *http.Client, endpoint string, params url.Values) ([]byte, error) {
 	reqURL := fmt.Sprintf("%s%s", baseURL, endpoint)
 	if rawQuery := params.Encode(); rawQuery != "" {
 		reqURL += "?" + rawQuery
@@ -213,7 +315,7 @@ func get(ctx context.Context, endpoint string, params url.Values) ([]byte, error
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -4,10 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 )
 
+// ListPairs lists the pairs with http.DefaultClient, use (*Binance).ListPairs
+// to go through the client passed to New.
 func ListPairs(ctx context.Context, symbols ...string) ([]*Pair, error) {
+	return listPairs(ctx, http.DefaultClient, symbols...)
+}
+
+// GetPair gets a pair with http.DefaultClient, use (*Binance).GetPair
+// to go through the client passed to New.
+func GetPair(ctx context.Context, symbol string) (*Pair, error) {
+	return getPair(ctx, http.DefaultClient, symbol)
+}
+
+func (c *Binance) ListPairs(ctx context.Context, symbols ...string) ([]*Pair, error) {
+	return listPairs(ctx, c.client, symbols...)
+}
+
+func (c *Binance) GetPair(ctx context.Context, symbol string) (*Pair, error) {
+	return getPair(ctx, c.client, symbol)
+}
+
+func listPairs(ctx context.Context, client *http.Client, symbols ...string) ([]*Pair, error) {
 	params := url.Values{}
 	switch len(symbols) {
 	case 0:
@@ -20,7 +41,7 @@ func ListPairs(ctx context.Context, symbols ...string) ([]*Pair, error) {
 		params.Set("symbols", string(raw))
 	}
 
-	resp, err := get(ctx, "/api/v3/exchangeInfo", params)
+	resp, err := get(ctx, client, "/api/v3/exchangeInfo", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pairs: %w", err)
 	}
@@ -63,8 +84,8 @@ func ListPairs(ctx context.Context, symbols ...string) ([]*Pair, error) {
 	return pairs, nil
 }
 
-func GetPair(ctx context.Context, symbol string) (*Pair, error) {
-	pairs, err := ListPairs(ctx, symbol)
+func getPair(ctx context.Context, client *http.Client, symbol string) (*Pair, error) {
+	pairs, err := listPairs(ctx, client, symbol)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,174 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	streamBaseURL = "wss://stream.binance.com:9443"
+
+	streamMinBackoff = time.Second
+	streamMaxBackoff = 30 * time.Second
+)
+
+// Binance sends keys that differ only in case, e.g. "m" and "M". encoding/json
+// matches keys case-insensitively, so every key needs its own tagged field or
+// the uppercase one overwrites its lowercase twin.
+type (
+	TradeEvent struct {
+		EventType     string          `json:"e"`
+		UnixEventTime int64           `json:"E"`
+		Symbol        string          `json:"s"`
+		TradeID       int64           `json:"t"`
+		Price         decimal.Decimal `json:"p"`
+		Qty           decimal.Decimal `json:"q"`
+		BuyerOrderID  int64           `json:"b"`
+		SellerOrderID int64           `json:"a"`
+		UnixTradeTime int64           `json:"T"`
+		IsBuyerMaker  bool            `json:"m"`
+		Ignore        bool            `json:"M"`
+	}
+
+	KlineEvent struct {
+		EventType     string `json:"e"`
+		UnixEventTime int64  `json:"E"`
+		Symbol        string `json:"s"`
+		Kline         struct {
+			UnixStartTime int64           `json:"t"`
+			UnixCloseTime int64           `json:"T"`
+			Symbol        string          `json:"s"`
+			Interval      string          `json:"i"`
+			FirstTradeID  int64           `json:"f"`
+			LastTradeID   int64           `json:"L"`
+			Open          decimal.Decimal `json:"o"`
+			Close         decimal.Decimal `json:"c"`
+			High          decimal.Decimal `json:"h"`
+			Low           decimal.Decimal `json:"l"`
+			Volume        decimal.Decimal `json:"v"`
+			TradeCount    int64           `json:"n"`
+			IsClosed      bool            `json:"x"`
+			QuoteVolume   decimal.Decimal `json:"q"`
+			// volumes of the trades where the taker was the buyer
+			TakerBuyVolume      decimal.Decimal `json:"V"`
+			TakerBuyQuoteVolume decimal.Decimal `json:"Q"`
+			Ignore              string          `json:"B"`
+		} `json:"k"`
+	}
+
+	streamMessage struct {
+		Stream string          `json:"stream"`
+		Data   json.RawMessage `json:"data"`
+	}
+)
+
+// SubscribeTrades streams the trades of the symbols. The connection is
+// re-established automatically until ctx is done, then the channel is closed.
+func (c *Binance) SubscribeTrades(ctx context.Context, symbols ...string) (<-chan *TradeEvent, error) {
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@trade")
+	}
+	return subscribe[TradeEvent](ctx, c.dialer, streams)
+}
+
+// SubscribeKlines streams the klines of the symbols for an interval such as "1m" or "1h".
+// The connection is re-established automatically until ctx is done, then the channel is closed.
+func (c *Binance) SubscribeKlines(ctx context.Context, interval string, symbols ...string) (<-chan *KlineEvent, error) {
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@kline_"+interval)
+	}
+	return subscribe[KlineEvent](ctx, c.dialer, streams)
+}
+
+func subscribe[T any](ctx context.Context, dialer *websocket.Dialer, streams []string) (<-chan *T, error) {
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("at least one symbol is required")
+	}
+
+	streamURL := fmt.Sprintf("%s/stream?streams=%s", streamBaseURL, strings.Join(streams, "/"))
+	conn, _, err := dialer.DialContext(ctx, streamURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to stream: %w", err)
+	}
+
+	events := make(chan *T)
+	go func() {
+		defer close(events)
+
+		backoff := streamMinBackoff
+		for {
+			if err := readStream(ctx, conn, events); err != nil && ctx.Err() == nil {
+				slog.Warn("binance stream disconnected", "error", err)
+			}
+
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+
+				conn, _, err = dialer.DialContext(ctx, streamURL, nil)
+				if err == nil {
+					backoff = streamMinBackoff
+					break
+				}
+				slog.Warn("binance stream reconnect failed", "error", err)
+				backoff = min(backoff*2, streamMaxBackoff)
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func readStream[T any](ctx context.Context, conn *websocket.Conn, events chan<- *T) error {
+	defer conn.Close()
+
+	// unblock ReadMessage when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var msg streamMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			slog.Warn("failed to parse binance stream message", "error", err)
+			continue
+		}
+		event := new(T)
+		if err := json.Unmarshal(msg.Data, event); err != nil {
+			slog.Warn("failed to parse binance stream event", "stream", msg.Stream, "error", err)
+			continue
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
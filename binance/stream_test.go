@@ -0,0 +1,66 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestTradeEventDecode(t *testing.T) {
+	payload := `{"e":"trade","E":1672515782136,"s":"BNBBTC","t":12345,"p":"0.001","q":"100","b":88,"a":50,"T":1672515782136,"m":false,"M":true}`
+
+	var event TradeEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.IsBuyerMaker {
+		t.Errorf("IsBuyerMaker = true, want false")
+	}
+	if event.UnixEventTime != 1672515782136 || event.TradeID != 12345 {
+		t.Errorf("unexpected event time or trade id: %+v", event)
+	}
+	if event.Qty.String() != "100" || event.Price.String() != "0.001" {
+		t.Errorf("unexpected price or qty: %s, %s", event.Price, event.Qty)
+	}
+}
+
+func TestKlineEventDecode(t *testing.T) {
+	payload := `{"e":"kline","E":1672515782136,"s":"BNBBTC","k":{"t":1672515780000,"T":1672515839999,"s":"BNBBTC","i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,"x":false,"q":"1.0000","V":"500","Q":"0.500","B":"123456"}}`
+
+	var event KlineEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatal(err)
+	}
+	k := event.Kline
+	if k.Low.String() != "0.0015" {
+		t.Errorf("Low = %s, want 0.0015", k.Low)
+	}
+	if k.Volume.String() != "1000" || k.TakerBuyVolume.String() != "500" {
+		t.Errorf("Volume = %s, TakerBuyVolume = %s, want 1000, 500", k.Volume, k.TakerBuyVolume)
+	}
+	if k.QuoteVolume.String() != "1" || k.TakerBuyQuoteVolume.String() != "0.5" {
+		t.Errorf("QuoteVolume = %s, TakerBuyQuoteVolume = %s, want 1, 0.5", k.QuoteVolume, k.TakerBuyQuoteVolume)
+	}
+	if k.UnixStartTime != 1672515780000 || k.UnixCloseTime != 1672515839999 {
+		t.Errorf("unexpected kline times: %d, %d", k.UnixStartTime, k.UnixCloseTime)
+	}
+	if k.FirstTradeID != 100 || k.LastTradeID != 200 {
+		t.Errorf("unexpected trade ids: %d, %d", k.FirstTradeID, k.LastTradeID)
+	}
+}
+
+func TestStreamDialerUsesClientProxy(t *testing.T) {
+	proxyURL, _ := url.Parse("http://127.0.0.1:8080")
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	c := New("key", "secret", client)
+	req, _ := http.NewRequest("GET", "https://stream.binance.com:9443/stream", nil)
+	got, err := c.dialer.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.String() != proxyURL.String() {
+		t.Errorf("dialer proxy = %v, want %s", got, proxyURL)
+	}
+}
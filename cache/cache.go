@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// Cache stores JSON encoded values in redis under a namespaced key prefix.
+	Cache struct {
+		rdb    *redis.Client
+		prefix string
+	}
+)
+
+func New(rdb *redis.Client, prefix string) *Cache {
+	return &Cache{
+		rdb:    rdb,
+		prefix: prefix,
+	}
+}
+
+func (c *Cache) Key(key string) string {
+	if c.prefix == "" {
+		return key
+	}
+	return fmt.Sprintf("%s:%s", c.prefix, key)
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache value: %w", err)
+	}
+	return c.rdb.Set(ctx, c.Key(key), buf, ttl).Err()
+}
+
+// Get decodes the cached value into ptr. It returns false if the key doesn't exist.
+func (c *Cache) Get(ctx context.Context, key string, ptr any) (bool, error) {
+	buf, found, err := c.GetRaw(ctx, key)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := json.Unmarshal(buf, ptr); err != nil {
+		return false, fmt.Errorf("failed to unmarshal cache value: %w", err)
+	}
+	return true, nil
+}
+
+// GetRaw returns the stored bytes of key without decoding them, e.g. to read
+// values written without JSON encoding.
+func (c *Cache) GetRaw(ctx context.Context, key string) ([]byte, bool, error) {
+	buf, err := c.rdb.Get(ctx, c.Key(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return buf, true, nil
+}
+
+func (c *Cache) Del(ctx context.Context, key string) error {
+	return c.rdb.Del(ctx, c.Key(key)).Err()
+}
+
+// GetOrSet returns the cached value of key, or calls fn and caches its result for ttl.
+// Read and decode errors are treated as a miss and a failed write is only logged,
+// so the cache being down doesn't fail the caller.
+func GetOrSet[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	found, err := c.Get(ctx, key, &value)
+	if err != nil {
+		slog.Warn("[goutils.cache] failed to read cache, treating as a miss", "key", c.Key(key), "error", err)
+		var zero T
+		value = zero
+	}
+	if found {
+		return value, nil
+	}
+
+	value, err = fn(ctx)
+	if err != nil {
+		return value, err
+	}
+	if err := c.Set(ctx, key, value, ttl); err != nil {
+		slog.Warn("[goutils.cache] failed to write cache", "key", c.Key(key), "error", err)
+	}
+	return value, nil
+}
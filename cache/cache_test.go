@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGetOrSetRedisDown(t *testing.T) {
+	// nothing listens on port 1, every read and write fails
+	rdb := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		MaxRetries:  -1,
+		DialTimeout: 100 * time.Millisecond,
+	})
+	defer rdb.Close()
+	c := New(rdb, "test")
+
+	calls := 0
+	value, err := GetOrSet(context.Background(), c, "key", time.Minute, func(ctx context.Context) (string, error) {
+		calls++
+		return "fresh", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "fresh" || calls != 1 {
+		t.Errorf("value = %q, calls = %d, want fresh, 1", value, calls)
+	}
+}
@@ -0,0 +1,141 @@
+package coinbase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	AccountBalance struct {
+		Value    decimal.Decimal `json:"value"`
+		Currency string          `json:"currency"`
+	}
+
+	Account struct {
+		UUID             string         `json:"uuid"`
+		Name             string         `json:"name"`
+		Currency         string         `json:"currency"`
+		AvailableBalance AccountBalance `json:"available_balance"`
+		Hold             AccountBalance `json:"hold"`
+		Default          bool           `json:"default"`
+		Active           bool           `json:"active"`
+		Type             string         `json:"type"`
+		Ready            bool           `json:"ready"`
+	}
+
+	AccountsResponse struct {
+		Accounts []*Account `json:"accounts"`
+		HasNext  bool       `json:"has_next"`
+		Cursor   string     `json:"cursor"`
+		Size     int        `json:"size"`
+	}
+)
+
+// GetAccounts lists the accounts of the CDP API key, following the pagination cursor.
+func (cb *Coinbase) GetAccounts(ctx context.Context) ([]*Account, error) {
+	var accounts []*Account
+	cursor := ""
+	for {
+		params := url.Values{"limit": {"250"}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := cb.signedRequest(ctx, http.MethodGet, "/api/v3/brokerage/accounts", params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accounts: %w", err)
+		}
+
+		var resp AccountsResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+		}
+		accounts = append(accounts, resp.Accounts...)
+
+		if !resp.HasNext || resp.Cursor == "" {
+			return accounts, nil
+		}
+		cursor = resp.Cursor
+	}
+}
+
+// signedRequest sends a request authenticated with a CDP API key: APIKey is the
+// key name and APISecret the EC private key in PEM format.
+func (cb *Coinbase) signedRequest(ctx context.Context, method, path string, params url.Values) ([]byte, error) {
+	reqURL, err := url.Parse(cb.baseURL + path)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cb.buildJWT(method, reqURL.Host+reqURL.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawQuery := params.Encode(); rawQuery != "" {
+		reqURL.RawQuery = rawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := cb.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("coinbase API error: %s, body: %s", resp.Status, string(body))
+	}
+
+	return body, nil
+}
+
+func (cb *Coinbase) buildJWT(method, uri string) (string, error) {
+	// secrets copied from the CDP portal often contain escaped newlines
+	secret := strings.ReplaceAll(cb.cfg.APISecret, `\n`, "\n")
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse api secret: %w", err)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": cb.cfg.APIKey,
+		"iss": "cdp",
+		"nbf": now.Unix(),
+		"exp": now.Add(2 * time.Minute).Unix(),
+		"uri": fmt.Sprintf("%s %s", method, uri),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = cb.cfg.APIKey
+	token.Header["nonce"] = hex.EncodeToString(nonce)
+
+	return token.SignedString(key)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -40,23 +42,37 @@ type (
 	Config struct {
 		APIKey    string
 		APISecret string
+		// HTTPClient is used for all requests, a client with a 30s timeout is used when it's nil
+		HTTPClient *http.Client
+		// BaseURL overrides the production API, e.g. for a sandbox or a mock server
+		BaseURL string
 	}
 
 	Coinbase struct {
-		cfg Config
+		cfg     Config
+		client  *http.Client
+		baseURL string
 	}
 )
 
 func New(cfg Config) *Coinbase {
-	cb := &Coinbase{cfg: cfg}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = apiBase
+	}
+	cb := &Coinbase{cfg: cfg, client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
 	return cb
 }
 
 func (cb *Coinbase) GetCryptoPrice(symbol string) (*CoinPriceResponse, error) {
 
-	url := fmt.Sprintf("%s/v2/prices/%s-USD/buy", apiBase, symbol)
+	url := fmt.Sprintf("%s/v2/prices/%s-USD/buy", cb.baseURL, symbol)
 
-	response, err := http.Get(url)
+	response, err := cb.client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("error making request: %v", err)
 	}
@@ -80,8 +96,8 @@ func (cb *Coinbase) GetCryptoPrice(symbol string) (*CoinPriceResponse, error) {
 
 func (cb *Coinbase) GetFiatRatesToUSD() (*CurrencyItems, error) {
 	// get fiat currencies
-	url := fmt.Sprintf("%s/v2/currencies", apiBase)
-	response, err := http.Get(url)
+	url := fmt.Sprintf("%s/v2/currencies", cb.baseURL)
+	response, err := cb.client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("error making request: %v", err)
 	}
@@ -104,9 +120,9 @@ func (cb *Coinbase) GetFiatRatesToUSD() (*CurrencyItems, error) {
 	}
 
 	// get exchange rates
-	url = fmt.Sprintf("%s/v2/exchange-rates?currency=USD", apiBase)
+	url = fmt.Sprintf("%s/v2/exchange-rates?currency=USD", cb.baseURL)
 
-	response, err = http.Get(url)
+	response, err = cb.client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("error making request: %v", err)
 	}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type (
+	Checker interface {
+		Name() string
+		Check(ctx context.Context) error
+	}
+
+	Aggregator struct {
+		checkers []Checker
+		timeout  time.Duration
+	}
+
+	Status struct {
+		OK     bool              `json:"ok"`
+		Checks map[string]string `json:"checks"`
+	}
+
+	funcChecker struct {
+		name string
+		fn   func(ctx context.Context) error
+	}
+)
+
+// NewChecker wraps a check function as a Checker.
+func NewChecker(name string, fn func(ctx context.Context) error) Checker {
+	return &funcChecker{name: name, fn: fn}
+}
+
+func (c *funcChecker) Name() string {
+	return c.name
+}
+
+func (c *funcChecker) Check(ctx context.Context) error {
+	return c.fn(ctx)
+}
+
+func New(timeout time.Duration, checkers ...Checker) *Aggregator {
+	if timeout == 0 {
+		timeout = time.Second * 5
+	}
+	return &Aggregator{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
+
+func (a *Aggregator) Add(checkers ...Checker) {
+	a.checkers = append(a.checkers, checkers...)
+}
+
+// Check runs all checkers concurrently. Each entry of Status.Checks is "ok" or the error message.
+// Checkers that haven't returned when the timeout expires are reported as "timeout".
+func (a *Aggregator) Check(ctx context.Context) *Status {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	status := &Status{
+		OK:     true,
+		Checks: make(map[string]string, len(a.checkers)),
+	}
+
+	// buffered so checkers that outlive the timeout can still send and exit
+	resultChan := make(chan struct {
+		name   string
+		result string
+	}, len(a.checkers))
+
+	for _, checker := range a.checkers {
+		status.Checks[checker.Name()] = "timeout"
+		go func(checker Checker) {
+			result := "ok"
+			if err := checker.Check(ctx); err != nil {
+				result = err.Error()
+			}
+			resultChan <- struct {
+				name   string
+				result string
+			}{name: checker.Name(), result: result}
+		}(checker)
+	}
+
+	for pending := len(a.checkers); pending > 0; pending-- {
+		select {
+		case <-ctx.Done():
+			status.OK = false
+			return status
+		case r := <-resultChan:
+			if r.result != "ok" {
+				status.OK = false
+			}
+			status.Checks[r.name] = r.result
+		}
+	}
+
+	return status
+}
+
+// Handler serves the aggregated status as JSON, with 503 if any check fails.
+func (a *Aggregator) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := a.Check(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if !status.OK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(status)
+	}
+}
@@ -0,0 +1,39 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAggregatorCheckTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	a := New(50*time.Millisecond,
+		NewChecker("fast", func(ctx context.Context) error { return nil }),
+		NewChecker("broken", func(ctx context.Context) error { return errors.New("down") }),
+		// ignores ctx, like a client with its own longer timeout
+		NewChecker("stuck", func(ctx context.Context) error {
+			<-block
+			return nil
+		}),
+	)
+
+	start := time.Now()
+	status := a.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Check took %s, want it bounded by the timeout", elapsed)
+	}
+
+	if status.OK {
+		t.Fatal("status.OK = true, want false")
+	}
+	want := map[string]string{"fast": "ok", "broken": "down", "stuck": "timeout"}
+	for name, result := range want {
+		if status.Checks[name] != result {
+			t.Errorf("Checks[%q] = %q, want %q", name, status.Checks[name], result)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package qdrant
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+const (
+	exportScrollLimit uint32 = 256
+	importBatchSize          = 128
+)
+
+// exportDouble always encodes with a fraction or exponent, so integral doubles
+// like 2.0 are not read back as integers.
+type exportDouble float64
+
+func (d exportDouble) MarshalJSON() ([]byte, error) {
+	buf, err := json.Marshal(float64(d))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.ContainsAny(string(buf), ".eE") {
+		buf = append(buf, ".0"...)
+	}
+	return buf, nil
+}
+
+type exportRecord struct {
+	ID      uint64         `json:"id,omitempty"`
+	UUID    string         `json:"uuid,omitempty"`
+	Vector  []float32      `json:"vector"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+// ExportCollection scrolls all points of a collection and writes them to w as
+// newline-delimited JSON.
+func (c *QdrantClient) ExportCollection(ctx context.Context, collectionName string, w io.Writer) error {
+	pointsClient := pb.NewPointsClient(c.Conn)
+	enc := json.NewEncoder(w)
+	limit := exportScrollLimit
+
+	var offset *pb.PointId
+	for {
+		resp, err := pointsClient.Scroll(ctx, &pb.ScrollPoints{
+			CollectionName: collectionName,
+			Offset:         offset,
+			Limit:          &limit,
+			WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+			WithVectors:    &pb.WithVectorsSelector{SelectorOptions: &pb.WithVectorsSelector_Enable{Enable: true}},
+		})
+		if err != nil {
+			slog.Error("could not scroll points", "collection", collectionName, "error", err)
+			return err
+		}
+
+		for _, p := range resp.GetResult() {
+			record := exportRecord{
+				ID:      p.GetId().GetNum(),
+				UUID:    p.GetId().GetUuid(),
+				Vector:  p.GetVectors().GetVector().GetData(),
+				Payload: make(map[string]any, len(p.GetPayload())),
+			}
+			for k, v := range p.GetPayload() {
+				record.Payload[k] = pbValueToAny(v)
+			}
+			if err := enc.Encode(record); err != nil {
+				return fmt.Errorf("failed to write point: %w", err)
+			}
+		}
+
+		offset = resp.GetNextPageOffset()
+		if offset == nil {
+			return nil
+		}
+	}
+}
+
+// ImportCollection reads newline-delimited JSON written by ExportCollection
+// and upserts the points into the collection in batches.
+func (c *QdrantClient) ImportCollection(ctx context.Context, collectionName string, r io.Reader) error {
+	pointsClient := pb.NewPointsClient(c.Conn)
+	wait := true
+	batch := make([]*pb.PointStruct, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := pointsClient.Upsert(ctx, &pb.UpsertPoints{
+			CollectionName: collectionName,
+			Wait:           &wait,
+			Points:         batch,
+		}); err != nil {
+			slog.Error("could not upsert points", "collection", collectionName, "error", err)
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	dec := newExportDecoder(r)
+	for {
+		var record exportRecord
+		if err := dec.Decode(&record); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("failed to read point: %w", err)
+		}
+
+		var id *pb.PointId
+		if record.UUID != "" {
+			id = &pb.PointId{PointIdOptions: &pb.PointId_Uuid{Uuid: record.UUID}}
+		} else {
+			id = &pb.PointId{PointIdOptions: &pb.PointId_Num{Num: record.ID}}
+		}
+
+		payload := make(map[string]*pb.Value, len(record.Payload))
+		for k, v := range record.Payload {
+			payload[k] = anyToPbValue(v)
+		}
+
+		batch = append(batch, &pb.PointStruct{
+			Id:      id,
+			Vectors: &pb.Vectors{VectorsOptions: &pb.Vectors_Vector{Vector: &pb.Vector{Data: record.Vector}}},
+			Payload: payload,
+		})
+		if len(batch) >= importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
+
+// newExportDecoder decodes payload numbers as json.Number, so large integers
+// such as snowflake IDs keep their precision.
+func newExportDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	dec.UseNumber()
+	return dec
+}
+
+func pbValueToAny(v *pb.Value) any {
+	switch kind := v.GetKind().(type) {
+	case *pb.Value_IntegerValue:
+		return kind.IntegerValue
+	case *pb.Value_DoubleValue:
+		return exportDouble(kind.DoubleValue)
+	case *pb.Value_BoolValue:
+		return kind.BoolValue
+	case *pb.Value_StringValue:
+		return kind.StringValue
+	case *pb.Value_ListValue:
+		list := make([]any, 0, len(kind.ListValue.GetValues()))
+		for _, item := range kind.ListValue.GetValues() {
+			list = append(list, pbValueToAny(item))
+		}
+		return list
+	case *pb.Value_StructValue:
+		m := make(map[string]any, len(kind.StructValue.GetFields()))
+		for k, item := range kind.StructValue.GetFields() {
+			m[k] = pbValueToAny(item)
+		}
+		return m
+	}
+	return nil
+}
+
+func anyToPbValue(v any) *pb.Value {
+	switch val := v.(type) {
+	case bool:
+		return &pb.Value{Kind: &pb.Value_BoolValue{BoolValue: val}}
+	case string:
+		return &pb.Value{Kind: &pb.Value_StringValue{StringValue: val}}
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return &pb.Value{Kind: &pb.Value_IntegerValue{IntegerValue: i}}
+		}
+		f, _ := val.Float64()
+		return &pb.Value{Kind: &pb.Value_DoubleValue{DoubleValue: f}}
+	case float64:
+		return &pb.Value{Kind: &pb.Value_DoubleValue{DoubleValue: val}}
+	case []any:
+		values := make([]*pb.Value, 0, len(val))
+		for _, item := range val {
+			values = append(values, anyToPbValue(item))
+		}
+		return &pb.Value{Kind: &pb.Value_ListValue{ListValue: &pb.ListValue{Values: values}}}
+	case map[string]any:
+		fields := make(map[string]*pb.Value, len(val))
+		for k, item := range val {
+			fields[k] = anyToPbValue(item)
+		}
+		return &pb.Value{Kind: &pb.Value_StructValue{StructValue: &pb.Struct{Fields: fields}}}
+	}
+	return &pb.Value{Kind: &pb.Value_NullValue{}}
+}
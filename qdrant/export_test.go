@@ -0,0 +1,47 @@
+package qdrant
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	pb "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExportRecordRoundTrip(t *testing.T) {
+	payload := map[string]*pb.Value{
+		"tweet_id": {Kind: &pb.Value_IntegerValue{IntegerValue: 1849213387123456789}},
+		"score":    {Kind: &pb.Value_DoubleValue{DoubleValue: 2.0}},
+		"ratio":    {Kind: &pb.Value_DoubleValue{DoubleValue: 0.25}},
+		"title":    {Kind: &pb.Value_StringValue{StringValue: "hello"}},
+		"tags": {Kind: &pb.Value_ListValue{ListValue: &pb.ListValue{Values: []*pb.Value{
+			{Kind: &pb.Value_IntegerValue{IntegerValue: -9007199254740993}},
+			{Kind: &pb.Value_BoolValue{BoolValue: true}},
+		}}}},
+		"meta": {Kind: &pb.Value_StructValue{StructValue: &pb.Struct{Fields: map[string]*pb.Value{
+			"weight": {Kind: &pb.Value_DoubleValue{DoubleValue: 1e21}},
+		}}}},
+	}
+
+	record := exportRecord{ID: 1, Vector: []float32{0.1}, Payload: map[string]any{}}
+	for k, v := range payload {
+		record.Payload[k] = pbValueToAny(v)
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(record); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded exportRecord
+	if err := newExportDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range payload {
+		got := anyToPbValue(decoded.Payload[k])
+		if !proto.Equal(got, want) {
+			t.Errorf("payload %q = %v, want %v", k, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package qdrant
+
+import (
+	pb "github.com/qdrant/go-client/qdrant"
+)
+
+// MatchKeyword matches points whose payload key equals the keyword.
+func MatchKeyword(key, value string) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Match: &pb.Match{MatchValue: &pb.Match_Keyword{Keyword: value}},
+	})
+}
+
+// MatchInt matches points whose payload key equals the integer.
+func MatchInt(key string, value int64) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Match: &pb.Match{MatchValue: &pb.Match_Integer{Integer: value}},
+	})
+}
+
+// MatchBool matches points whose payload key equals the boolean.
+func MatchBool(key string, value bool) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Match: &pb.Match{MatchValue: &pb.Match_Boolean{Boolean: value}},
+	})
+}
+
+// MatchAnyKeyword matches points whose payload key equals any of the keywords.
+func MatchAnyKeyword(key string, values ...string) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Match: &pb.Match{MatchValue: &pb.Match_Keywords{Keywords: &pb.RepeatedStrings{Strings: values}}},
+	})
+}
+
+// MatchRange matches points whose payload key is between gte and lte, inclusive.
+func MatchRange(key string, gte, lte float64) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Range: &pb.Range{Gte: &gte, Lte: &lte},
+	})
+}
+
+// MatchGte matches points whose payload key is greater than or equal to gte.
+func MatchGte(key string, gte float64) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Range: &pb.Range{Gte: &gte},
+	})
+}
+
+// MatchLte matches points whose payload key is less than or equal to lte.
+func MatchLte(key string, lte float64) *pb.Condition {
+	return fieldCondition(&pb.FieldCondition{
+		Key:   key,
+		Range: &pb.Range{Lte: &lte},
+	})
+}
+
+// MustAll returns a filter matching points that satisfy all conditions.
+func MustAll(conditions ...*pb.Condition) *pb.Filter {
+	return &pb.Filter{Must: conditions}
+}
+
+// Should returns a filter matching points that satisfy at least one condition.
+func Should(conditions ...*pb.Condition) *pb.Filter {
+	return &pb.Filter{Should: conditions}
+}
+
+// MustNot returns a filter matching points that satisfy none of the conditions.
+func MustNot(conditions ...*pb.Condition) *pb.Filter {
+	return &pb.Filter{MustNot: conditions}
+}
+
+// Nested wraps a filter into a condition, so filters can be combined,
+// e.g. MustAll(MatchKeyword("lang", "en"), Nested(Should(...))).
+func Nested(filter *pb.Filter) *pb.Condition {
+	return &pb.Condition{ConditionOneOf: &pb.Condition_Filter{Filter: filter}}
+}
+
+func fieldCondition(field *pb.FieldCondition) *pb.Condition {
+	return &pb.Condition{ConditionOneOf: &pb.Condition_Field{Field: field}}
+}
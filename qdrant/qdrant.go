@@ -5,12 +5,23 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/lyricat/goutils/health"
 	pb "github.com/qdrant/go-client/qdrant"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultSearchTopK uint64 = 10
+	// maxSearchWindow bounds offset + limit of a search, deep pages are slow in qdrant
+	maxSearchWindow uint64 = 10000
 )
 
 type (
@@ -25,13 +36,19 @@ type (
 	Config struct {
 		Addr   string
 		APIKey string
+		// APIKeyFunc, if set, is called on every request to get the current
+		// API key, so the key can be rotated without reconnecting.
+		APIKeyFunc func() string
+		// DefaultTopK is the limit of searches whose TopK is 0, 10 if not set
+		DefaultTopK uint64
 	}
 
 	QdrantClient struct {
-		Addr   string
-		APIKey string
-		ColCli pb.CollectionsClient
-		Conn   *grpc.ClientConn
+		Addr        string
+		APIKey      string
+		DefaultTopK uint64
+		ColCli      pb.CollectionsClient
+		Conn        *grpc.ClientConn
 	}
 
 	CommonParams struct {
@@ -41,8 +58,24 @@ type (
 		Selector       *pb.PointsSelector
 	}
 
+	// ReadOptions selects what is returned with the points. WithVectors
+	// overrides the default of the method when set, WithPayloadKeys limits
+	// the payload to the given keys.
+	ReadOptions struct {
+		WithVectors     *bool
+		WithPayloadKeys []string
+	}
+
 	GetPointsParams struct {
 		CommonParams
+		ReadOptions
+	}
+
+	GetPointsByIDsParams struct {
+		CollectionName string
+		IDs            []uint64
+		UUIDs          []string
+		ReadOptions
 	}
 
 	UpsertPointsParams struct {
@@ -61,6 +94,18 @@ type (
 		CommonParams
 	}
 
+	SetPayloadParams struct {
+		CommonParams
+		Payload map[string]UpsertPointPayloadItem
+		Wait    bool
+	}
+
+	DeletePayloadKeysParams struct {
+		CommonParams
+		Keys []string
+		Wait bool
+	}
+
 	SearchPointsParams struct {
 		CollectionName string
 		Vector         []float32
@@ -68,12 +113,47 @@ type (
 		Key            string
 		Value          int64
 		Offset         uint64
+		// Filter is used instead of the Key/Value match when set
+		Filter *pb.Filter
+		ReadOptions
+	}
+
+	// Embedder turns text into a vector, e.g. *ai.Instant
+	Embedder interface {
+		GetEmbeddings(ctx context.Context, input []string) ([]float32, error)
+	}
+
+	SearchByTextParams struct {
+		SearchPointsParams
+		Query string
+	}
+
+	RecommendPointsParams struct {
+		CollectionName string
+		PositiveIDs    []uint64
+		PositiveUUIDs  []string
+		NegativeIDs    []uint64
+		NegativeUUIDs  []string
+		Filter         *pb.Filter
+		TopK           uint64
+		ReadOptions
 	}
 
 	CreateCollectionParams struct {
 		CollectionName string
 		VectorSize     uint64
 		Indexes        []CreateCollectionIndexItem
+		IfNotExists    bool
+		// OptimizersConfig replaces the default optimizer config (2 segments) when set
+		OptimizersConfig *pb.OptimizersConfigDiff
+		HnswConfig       *pb.HnswConfigDiff
+		OnDiskVectors    *bool
+		OnDiskPayload    *bool
+	}
+
+	DeleteCollectionParams struct {
+		CollectionName string
+		IgnoreMissing  bool
 	}
 
 	CreateCollectionIndexItem struct {
@@ -116,6 +196,23 @@ func (p *CommonParams) GetPbSelector() (*pb.PointsSelector, error) {
 	return selector, nil
 }
 
+func (o *ReadOptions) GetPbVectorsSelector(defaultEnable bool) *pb.WithVectorsSelector {
+	enable := defaultEnable
+	if o.WithVectors != nil {
+		enable = *o.WithVectors
+	}
+	return &pb.WithVectorsSelector{SelectorOptions: &pb.WithVectorsSelector_Enable{Enable: enable}}
+}
+
+func (o *ReadOptions) GetPbPayloadSelector() *pb.WithPayloadSelector {
+	if len(o.WithPayloadKeys) != 0 {
+		return &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Include{
+			Include: &pb.PayloadIncludeSelector{Fields: o.WithPayloadKeys},
+		}}
+	}
+	return &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}}
+}
+
 func (p *CommonParams) GetPbPointID() (*pb.PointId, error) {
 	var id *pb.PointId
 	if p.PointID > 0 {
@@ -129,16 +226,79 @@ func (p *CommonParams) GetPbPointID() (*pb.PointId, error) {
 	return id, nil
 }
 
-func genInterceptor(apiKey string) grpc.UnaryClientInterceptor {
+func buildPbPointIDs(ids []uint64, uuids []string) []*pb.PointId {
+	pointIDs := make([]*pb.PointId, 0, len(ids)+len(uuids))
+	for _, id := range ids {
+		pointIDs = append(pointIDs, &pb.PointId{PointIdOptions: &pb.PointId_Num{Num: id}})
+	}
+	for _, uuid := range uuids {
+		pointIDs = append(pointIDs, &pb.PointId{PointIdOptions: &pb.PointId_Uuid{Uuid: uuid}})
+	}
+	return pointIDs
+}
+
+func pointIDKey(id *pb.PointId) string {
+	if uuid := id.GetUuid(); uuid != "" {
+		return strings.ToLower(uuid)
+	}
+	return strconv.FormatUint(id.GetNum(), 10)
+}
+
+func buildPbPayload(items map[string]UpsertPointPayloadItem) map[string]*pb.Value {
+	payload := make(map[string]*pb.Value)
+	for k, v := range items {
+		switch v.Type {
+		case "int":
+			vint := int64(v.Value.(int64))
+			payload[k] = &pb.Value{
+				Kind: &pb.Value_IntegerValue{IntegerValue: vint},
+			}
+		case "uint":
+			vint := int64(v.Value.(uint64))
+			payload[k] = &pb.Value{
+				Kind: &pb.Value_IntegerValue{IntegerValue: vint},
+			}
+		case "double":
+			payload[k] = &pb.Value{
+				Kind: &pb.Value_DoubleValue{DoubleValue: v.Value.(float64)},
+			}
+		case "bool":
+			payload[k] = &pb.Value{
+				Kind: &pb.Value_BoolValue{BoolValue: v.Value.(bool)},
+			}
+		case "text":
+			payload[k] = &pb.Value{
+				Kind: &pb.Value_StringValue{StringValue: v.Value.(string)},
+			}
+		}
+	}
+	return payload
+}
+
+func genInterceptor(apiKey func() string) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		newCtx := metadata.AppendToOutgoingContext(ctx, "api-key", apiKey)
-		return invoker(newCtx, method, req, reply, cc, opts...)
+		key := apiKey()
+		newCtx := metadata.AppendToOutgoingContext(ctx, "api-key", key)
+		err := invoker(newCtx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		// the key may have been rotated while the request was in flight, retry once with the new one
+		if newKey := apiKey(); newKey != key {
+			newCtx = metadata.AppendToOutgoingContext(ctx, "api-key", newKey)
+			return invoker(newCtx, method, req, reply, cc, opts...)
+		}
+		return err
 	}
 }
 
 func New(cfg Config) *QdrantClient {
 	config := &tls.Config{}
-	interceptor := genInterceptor(cfg.APIKey)
+	apiKeyFunc := cfg.APIKeyFunc
+	if apiKeyFunc == nil {
+		apiKeyFunc = func() string { return cfg.APIKey }
+	}
+	interceptor := genInterceptor(apiKeyFunc)
 	conn, err := grpc.NewClient(cfg.Addr, grpc.WithTransportCredentials(credentials.NewTLS(config)), grpc.WithUnaryInterceptor(interceptor))
 	if err != nil {
 		slog.Error("did not connect", "error", err)
@@ -147,20 +307,44 @@ func New(cfg Config) *QdrantClient {
 
 	ColCli := pb.NewCollectionsClient(conn)
 
+	if cfg.DefaultTopK == 0 {
+		cfg.DefaultTopK = defaultSearchTopK
+	}
+
 	return &QdrantClient{
-		Addr:   cfg.Addr,
-		APIKey: cfg.APIKey,
-		ColCli: ColCli,
-		Conn:   conn,
+		Addr:        cfg.Addr,
+		APIKey:      cfg.APIKey,
+		DefaultTopK: cfg.DefaultTopK,
+		ColCli:      ColCli,
+		Conn:        conn,
 	}
 }
 
-func (c *QdrantClient) Check() (string, error) {
-	qdrantClient := pb.NewQdrantClient(c.Conn)
+// searchLimit returns the limit of a search, using DefaultTopK when topK is 0.
+func (c *QdrantClient) searchLimit(topK, offset uint64) (uint64, error) {
+	if topK == 0 {
+		topK = c.DefaultTopK
+		if topK == 0 {
+			topK = defaultSearchTopK
+		}
+	}
+	if topK > maxSearchWindow || offset > maxSearchWindow-topK {
+		return 0, fmt.Errorf("invalid search window: offset %d + top k %d exceeds %d", offset, topK, maxSearchWindow)
+	}
+	return topK, nil
+}
 
+func (c *QdrantClient) Check() (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
+	return c.HealthCheck(ctx)
+}
+
+// HealthCheck is Check bounded by ctx, it returns the version of the qdrant server.
+func (c *QdrantClient) HealthCheck(ctx context.Context) (string, error) {
+	qdrantClient := pb.NewQdrantClient(c.Conn)
+
 	healthCheckResult, err := qdrantClient.HealthCheck(ctx, &pb.HealthCheckRequest{})
 	if err != nil {
 		slog.Error("could not get health", "error", err)
@@ -169,8 +353,17 @@ func (c *QdrantClient) Check() (string, error) {
 	return healthCheckResult.GetVersion(), nil
 }
 
-func (c *QdrantClient) Close() {
-	c.Conn.Close()
+// HealthChecker returns a health.Checker named "qdrant" backed by HealthCheck.
+func (c *QdrantClient) HealthChecker() health.Checker {
+	return health.NewChecker("qdrant", func(ctx context.Context) error {
+		_, err := c.HealthCheck(ctx)
+		return err
+	})
+}
+
+// Close closes the grpc connection, it implements io.Closer.
+func (c *QdrantClient) Close() error {
+	return c.Conn.Close()
 }
 
 func (c *QdrantClient) GetPoints(ctx context.Context, params GetPointsParams) (*QdrantPoint, error) {
@@ -183,8 +376,8 @@ func (c *QdrantClient) GetPoints(ctx context.Context, params GetPointsParams) (*
 	pointsById, err := pointsClient.Get(ctx, &pb.GetPoints{
 		CollectionName: params.CollectionName,
 		Ids:            ids,
-		WithVectors:    &pb.WithVectorsSelector{SelectorOptions: &pb.WithVectorsSelector_Enable{Enable: true}},
-		WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+		WithVectors:    params.GetPbVectorsSelector(true),
+		WithPayload:    params.GetPbPayloadSelector(),
 	})
 	if err != nil {
 		slog.Error("could not retrieve points", "error", err)
@@ -199,35 +392,43 @@ func (c *QdrantClient) GetPoints(ctx context.Context, params GetPointsParams) (*
 	return nil, nil
 }
 
-func (c *QdrantClient) UpsertPoints(ctx context.Context, params UpsertPointsParams) error {
-	payload := make(map[string]*pb.Value)
-	for k, v := range params.Payload {
-		switch v.Type {
-		case "int":
-			vint := int64(v.Value.(int64))
-			payload[k] = &pb.Value{
-				Kind: &pb.Value_IntegerValue{IntegerValue: vint},
-			}
-		case "uint":
-			vint := int64(v.Value.(uint64))
-			payload[k] = &pb.Value{
-				Kind: &pb.Value_IntegerValue{IntegerValue: vint},
-			}
-		case "double":
-			payload[k] = &pb.Value{
-				Kind: &pb.Value_DoubleValue{DoubleValue: v.Value.(float64)},
-			}
-		case "bool":
-			payload[k] = &pb.Value{
-				Kind: &pb.Value_BoolValue{BoolValue: v.Value.(bool)},
-			}
-		case "text":
-			payload[k] = &pb.Value{
-				Kind: &pb.Value_StringValue{StringValue: v.Value.(string)},
-			}
-		}
+// GetPointsByIDs retrieves multiple points in one call. The result follows the
+// order of IDs then UUIDs, with nil for points that were not found.
+func (c *QdrantClient) GetPointsByIDs(ctx context.Context, params GetPointsByIDsParams) ([]*QdrantPoint, error) {
+	ids := buildPbPointIDs(params.IDs, params.UUIDs)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("point IDs or UUIDs are required")
+	}
+
+	pointsClient := pb.NewPointsClient(c.Conn)
+	pointsById, err := pointsClient.Get(ctx, &pb.GetPoints{
+		CollectionName: params.CollectionName,
+		Ids:            ids,
+		WithVectors:    params.GetPbVectorsSelector(true),
+		WithPayload:    params.GetPbPayloadSelector(),
+	})
+	if err != nil {
+		slog.Error("could not retrieve points", "error", err)
+		return nil, err
+	}
+
+	found := make(map[string]*QdrantPoint, len(pointsById.GetResult()))
+	for _, p := range pointsById.GetResult() {
+		qp := &QdrantPoint{}
+		qp.LoadFromRetrievedPoint(p)
+		found[pointIDKey(p.GetId())] = qp
 	}
 
+	qpList := make([]*QdrantPoint, len(ids))
+	for i, id := range ids {
+		qpList[i] = found[pointIDKey(id)]
+	}
+	return qpList, nil
+}
+
+func (c *QdrantClient) UpsertPoints(ctx context.Context, params UpsertPointsParams) error {
+	payload := buildPbPayload(params.Payload)
+
 	waitUpsert := params.WaitUpsert
 
 	id, err := params.GetPbPointID()
@@ -273,37 +474,101 @@ func (c *QdrantClient) DeletePoints(ctx context.Context, params DeletePointsPara
 	return nil
 }
 
+func (c *QdrantClient) SetPayload(ctx context.Context, params SetPayloadParams) error {
+	selector, err := params.GetPbSelector()
+	if err != nil {
+		return err
+	}
+
+	wait := params.Wait
+	pointsClient := pb.NewPointsClient(c.Conn)
+	if _, err := pointsClient.SetPayload(ctx, &pb.SetPayloadPoints{
+		CollectionName: params.CollectionName,
+		Wait:           &wait,
+		Payload:        buildPbPayload(params.Payload),
+		PointsSelector: selector,
+	}); err != nil {
+		slog.Error("could not set payload", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *QdrantClient) OverwritePayload(ctx context.Context, params SetPayloadParams) error {
+	selector, err := params.GetPbSelector()
+	if err != nil {
+		return err
+	}
+
+	wait := params.Wait
+	pointsClient := pb.NewPointsClient(c.Conn)
+	if _, err := pointsClient.OverwritePayload(ctx, &pb.SetPayloadPoints{
+		CollectionName: params.CollectionName,
+		Wait:           &wait,
+		Payload:        buildPbPayload(params.Payload),
+		PointsSelector: selector,
+	}); err != nil {
+		slog.Error("could not overwrite payload", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *QdrantClient) DeletePayloadKeys(ctx context.Context, params DeletePayloadKeysParams) error {
+	if len(params.Keys) == 0 {
+		return fmt.Errorf("payload keys are required")
+	}
+
+	selector, err := params.GetPbSelector()
+	if err != nil {
+		return err
+	}
+
+	wait := params.Wait
+	pointsClient := pb.NewPointsClient(c.Conn)
+	if _, err := pointsClient.DeletePayload(ctx, &pb.DeletePayloadPoints{
+		CollectionName: params.CollectionName,
+		Wait:           &wait,
+		Keys:           params.Keys,
+		PointsSelector: selector,
+	}); err != nil {
+		slog.Error("could not delete payload keys", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *QdrantClient) SearchPointsWithFilter(ctx context.Context, params SearchPointsParams) ([]*QdrantPoint, error) {
+	if len(params.Vector) == 0 {
+		return nil, fmt.Errorf("search vector is empty")
+	}
+	limit, err := c.searchLimit(params.TopK, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+
 	filter := &pb.Filter{}
-	if params.Key != "" {
-		filter = &pb.Filter{
-			Must: []*pb.Condition{
-				{
-					ConditionOneOf: &pb.Condition_Field{
-						Field: &pb.FieldCondition{
-							Key: params.Key,
-							Match: &pb.Match{
-								MatchValue: &pb.Match_Integer{
-									Integer: params.Value,
-								},
-							},
-						},
-					},
-				},
-			},
-		}
+	if params.Filter != nil {
+		filter = params.Filter
+	} else if params.Key != "" {
+		filter = MustAll(MatchInt(params.Key, params.Value))
 	}
 	pointsClient := pb.NewPointsClient(c.Conn)
 	filteredSearchResult, err := pointsClient.Search(ctx, &pb.SearchPoints{
 		CollectionName: params.CollectionName,
 		Vector:         params.Vector,
-		Limit:          params.TopK,
+		Limit:          limit,
 		Offset:         &params.Offset,
 		Filter:         filter,
-		WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+		WithPayload:    params.GetPbPayloadSelector(),
+		WithVectors:    params.GetPbVectorsSelector(false),
 	})
 	if err != nil {
 		slog.Error("could not search points", "error", err)
+		return nil, err
 	}
 
 	result := filteredSearchResult.GetResult()
@@ -316,61 +581,184 @@ func (c *QdrantClient) SearchPointsWithFilter(ctx context.Context, params Search
 	return qpList, nil
 }
 
+// SearchByText embeds the query with embedder and searches with the vector.
+func (c *QdrantClient) SearchByText(ctx context.Context, embedder Embedder, params SearchByTextParams) ([]*QdrantPoint, error) {
+	vector, err := embedder.GetEmbeddings(ctx, []string{params.Query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(vector) == 0 {
+		return nil, fmt.Errorf("empty embedding for query")
+	}
+
+	params.Vector = vector
+	return c.SearchPointsWithFilter(ctx, params.SearchPointsParams)
+}
+
+func (c *QdrantClient) RecommendPoints(ctx context.Context, params RecommendPointsParams) ([]*QdrantPoint, error) {
+	positive := buildPbPointIDs(params.PositiveIDs, params.PositiveUUIDs)
+	if len(positive) == 0 {
+		return nil, fmt.Errorf("at least one positive point is required")
+	}
+	limit, err := c.searchLimit(params.TopK, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pointsClient := pb.NewPointsClient(c.Conn)
+	recommendResult, err := pointsClient.Recommend(ctx, &pb.RecommendPoints{
+		CollectionName: params.CollectionName,
+		Positive:       positive,
+		Negative:       buildPbPointIDs(params.NegativeIDs, params.NegativeUUIDs),
+		Filter:         params.Filter,
+		Limit:          limit,
+		WithPayload:    params.GetPbPayloadSelector(),
+		WithVectors:    params.GetPbVectorsSelector(false),
+	})
+	if err != nil {
+		slog.Error("could not recommend points", "error", err)
+		return nil, err
+	}
+
+	result := recommendResult.GetResult()
+	qpList := make([]*QdrantPoint, 0, len(result))
+	for _, p := range result {
+		qp := &QdrantPoint{}
+		qp.LoadFromScoredPoint(p)
+		qpList = append(qpList, qp)
+	}
+	return qpList, nil
+}
+
+func (c *QdrantClient) CollectionExists(ctx context.Context, collectionName string) (bool, error) {
+	resp, err := c.ColCli.CollectionExists(ctx, &pb.CollectionExistsRequest{
+		CollectionName: collectionName,
+	})
+	if err != nil {
+		slog.Error("could not check collection existence", "collection", collectionName, "error", err)
+		return false, err
+	}
+	return resp.GetResult().GetExists(), nil
+}
+
 func (c *QdrantClient) CreateCollection(ctx context.Context, params CreateCollectionParams) error {
 	// Create new collection
 	var defaultSegmentNumber uint64 = 2
+	optimizersConfig := params.OptimizersConfig
+	if optimizersConfig == nil {
+		optimizersConfig = &pb.OptimizersConfigDiff{
+			DefaultSegmentNumber: &defaultSegmentNumber,
+		}
+	}
 	cols := []string{params.CollectionName}
 	for _, collectionName := range cols {
+		if params.IfNotExists {
+			exists, err := c.CollectionExists(ctx, collectionName)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+		}
+
 		_, err := c.ColCli.Create(ctx, &pb.CreateCollection{
 			CollectionName: collectionName,
 			VectorsConfig: &pb.VectorsConfig{Config: &pb.VectorsConfig_Params{
 				Params: &pb.VectorParams{
 					Size:     params.VectorSize,
 					Distance: pb.Distance_Dot,
+					OnDisk:   params.OnDiskVectors,
 				},
 			}},
-			OptimizersConfig: &pb.OptimizersConfigDiff{
-				DefaultSegmentNumber: &defaultSegmentNumber,
-			},
+			OptimizersConfig: optimizersConfig,
+			HnswConfig:       params.HnswConfig,
+			OnDiskPayload:    params.OnDiskPayload,
 		})
 		if err != nil {
 			slog.Error("could not create collection", "error", err)
 			return err
 		}
-		// Create string field index
-		pointsClient := pb.NewPointsClient(c.Conn)
+		// Create payload field indexes
 		for _, indexItem := range params.Indexes {
-			fieldType := pb.FieldType_FieldTypeKeyword
-			switch indexItem.Type {
-			case "int":
-				fieldType = pb.FieldType_FieldTypeInteger
-			case "float":
-				fieldType = pb.FieldType_FieldTypeFloat
-			case "bool":
-				fieldType = pb.FieldType_FieldTypeBool
-			case "text":
-				fieldType = pb.FieldType_FieldTypeText
-			case "keyword":
-				fieldType = pb.FieldType_FieldTypeKeyword
-			case "date":
-				fieldType = pb.FieldType_FieldTypeDatetime
-			case "geo":
-				fieldType = pb.FieldType_FieldTypeGeo
-			case "uuid":
-				fieldType = pb.FieldType_FieldTypeUuid
+			if err := c.CreateFieldIndex(ctx, collectionName, indexItem); err != nil {
+				return err
 			}
+		}
+	}
 
-			_, err := pointsClient.CreateFieldIndex(ctx, &pb.CreateFieldIndexCollection{
-				CollectionName: collectionName,
-				FieldName:      indexItem.Name,
-				FieldType:      &fieldType,
-			})
+	return nil
+}
 
-			if err != nil {
-				slog.Error("could not create index", "field", indexItem.Name, "error", err)
-				return err
-			}
+// CreateFieldIndex indexes a payload field of an existing collection.
+func (c *QdrantClient) CreateFieldIndex(ctx context.Context, collectionName string, indexItem CreateCollectionIndexItem) error {
+	fieldType := pb.FieldType_FieldTypeKeyword
+	switch indexItem.Type {
+	case "int":
+		fieldType = pb.FieldType_FieldTypeInteger
+	case "float":
+		fieldType = pb.FieldType_FieldTypeFloat
+	case "bool":
+		fieldType = pb.FieldType_FieldTypeBool
+	case "text":
+		fieldType = pb.FieldType_FieldTypeText
+	case "keyword":
+		fieldType = pb.FieldType_FieldTypeKeyword
+	case "date":
+		fieldType = pb.FieldType_FieldTypeDatetime
+	case "geo":
+		fieldType = pb.FieldType_FieldTypeGeo
+	case "uuid":
+		fieldType = pb.FieldType_FieldTypeUuid
+	}
+
+	wait := true
+	pointsClient := pb.NewPointsClient(c.Conn)
+	_, err := pointsClient.CreateFieldIndex(ctx, &pb.CreateFieldIndexCollection{
+		CollectionName: collectionName,
+		FieldName:      indexItem.Name,
+		FieldType:      &fieldType,
+		Wait:           &wait,
+	})
+	if err != nil {
+		slog.Error("could not create index", "field", indexItem.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *QdrantClient) DeleteFieldIndex(ctx context.Context, collectionName, fieldName string) error {
+	wait := true
+	pointsClient := pb.NewPointsClient(c.Conn)
+	_, err := pointsClient.DeleteFieldIndex(ctx, &pb.DeleteFieldIndexCollection{
+		CollectionName: collectionName,
+		FieldName:      fieldName,
+		Wait:           &wait,
+	})
+	if err != nil {
+		slog.Error("could not delete index", "field", fieldName, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *QdrantClient) DeleteCollection(ctx context.Context, params DeleteCollectionParams) error {
+	exists, err := c.CollectionExists(ctx, params.CollectionName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if params.IgnoreMissing {
+			return nil
 		}
+		return fmt.Errorf("collection %s does not exist", params.CollectionName)
+	}
+
+	if _, err := c.ColCli.Delete(ctx, &pb.DeleteCollection{
+		CollectionName: params.CollectionName,
+	}); err != nil {
+		slog.Error("could not delete collection", "collection", params.CollectionName, "error", err)
+		return err
 	}
 
 	return nil
@@ -0,0 +1,62 @@
+package shortid
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/speps/go-hashids/v2"
+)
+
+// ShortID wraps go-hashids, so IDs generated here are compatible with the
+// other hashids implementations.
+
+const (
+	DefaultAlphabet = hashids.DefaultAlphabet
+)
+
+type (
+	Config struct {
+		Salt      string
+		MinLength int
+		Alphabet  string
+	}
+
+	ShortID struct {
+		hd *hashids.HashID
+	}
+)
+
+func New(cfg Config) (*ShortID, error) {
+	if cfg.Alphabet == "" {
+		cfg.Alphabet = DefaultAlphabet
+	}
+	if cfg.MinLength < 0 {
+		return nil, errors.New("min length must not be negative")
+	}
+
+	hd, err := hashids.NewWithData(&hashids.HashIDData{
+		Alphabet:  cfg.Alphabet,
+		MinLength: cfg.MinLength,
+		Salt:      cfg.Salt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid shortid config: %w", err)
+	}
+	return &ShortID{hd: hd}, nil
+}
+
+func (s *ShortID) Encode(numbers []int64) (string, error) {
+	if len(numbers) == 0 {
+		return "", errors.New("no numbers to encode")
+	}
+	return s.hd.EncodeInt64(numbers)
+}
+
+// Decode decodes id back to numbers. It re-encodes the result and fails if it
+// doesn't match id, so ids made with another salt or alphabet are rejected.
+func (s *ShortID) Decode(id string) ([]int64, error) {
+	if id == "" {
+		return nil, errors.New("empty id")
+	}
+	return s.hd.DecodeInt64WithError(id)
+}
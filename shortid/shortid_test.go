@@ -0,0 +1,54 @@
+package shortid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		cfg     Config
+		numbers []int64
+		want    string
+	}{
+		{Config{Salt: "this is my salt"}, []int64{1, 2, 3}, "laHquq"},
+		{Config{Salt: "this is my salt"}, []int64{12345}, "NkK9"},
+		{Config{Salt: "this is my salt", MinLength: 8}, []int64{1}, "gB0NV05e"},
+		{Config{Salt: "this is my salt", Alphabet: "0123456789abcdef"}, []int64{1234567}, "b332db5"},
+	}
+
+	for _, tc := range testCases {
+		s, err := New(tc.cfg)
+		if err != nil {
+			t.Fatalf("New(%+v) error: %v", tc.cfg, err)
+		}
+		got, err := s.Encode(tc.numbers)
+		if err != nil {
+			t.Fatalf("Encode(%v) error: %v", tc.numbers, err)
+		}
+		if got != tc.want {
+			t.Errorf("Encode(%v) = %s, want %s", tc.numbers, got, tc.want)
+		}
+
+		decoded, err := s.Decode(got)
+		if err != nil {
+			t.Fatalf("Decode(%s) error: %v", got, err)
+		}
+		if !reflect.DeepEqual(decoded, tc.numbers) {
+			t.Errorf("Decode(%s) = %v, want %v", got, decoded, tc.numbers)
+		}
+	}
+}
+
+func TestDecodeRejectsForeignIDs(t *testing.T) {
+	a, _ := New(Config{Salt: "salt a", MinLength: 8})
+	b, _ := New(Config{Salt: "salt b", MinLength: 8})
+
+	id, err := a.Encode([]int64{42, 7})
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	if _, err := b.Decode(id); err == nil {
+		t.Errorf("Decode(%s) with another salt should fail", id)
+	}
+}
@@ -0,0 +1,56 @@
+package cursor
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// FetchFunc fetches the page at token and returns the token of the next page,
+	// an empty token means there are no more pages.
+	FetchFunc[T any] func(ctx context.Context, token string) (T, string, error)
+
+	// Cursor iterates the pages of a token-paginated API.
+	Cursor[T any] struct {
+		fetch     FetchFunc[T]
+		nextToken string
+		started   bool
+	}
+)
+
+// New creates a cursor starting at the first page.
+func New[T any](fetch FetchFunc[T]) *Cursor[T] {
+	return &Cursor[T]{fetch: fetch}
+}
+
+// NewFrom creates a cursor starting at the page of token.
+func NewFrom[T any](fetch FetchFunc[T], token string) *Cursor[T] {
+	return &Cursor[T]{fetch: fetch, nextToken: token}
+}
+
+// HasMore reports whether Next can fetch another page.
+func (c *Cursor[T]) HasMore() bool {
+	return !c.started || c.nextToken != ""
+}
+
+// NextToken returns the token of the next page, e.g. to resume later with NewFrom.
+func (c *Cursor[T]) NextToken() string {
+	return c.nextToken
+}
+
+// Next fetches the next page. The cursor only advances when the fetch succeeds,
+// so a failed page can be retried by calling Next again.
+func (c *Cursor[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	if !c.HasMore() {
+		return zero, fmt.Errorf("no more pages")
+	}
+
+	page, nextToken, err := c.fetch(ctx, c.nextToken)
+	if err != nil {
+		return zero, err
+	}
+	c.started = true
+	c.nextToken = nextToken
+	return page, nil
+}
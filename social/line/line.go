@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot/messaging_api"
@@ -18,11 +19,15 @@ type (
 		bot         *messaging_api.MessagingApiAPI
 		blobBot     *messaging_api.MessagingApiBlobAPI
 		accessToken string
+		httpClient  *http.Client
 	}
 	Config struct {
 		ChannelID  string
 		ChannelKey string
 		PrivateKey string
+		// HTTPClient is used for the token and messaging API requests,
+		// a client with a 30s timeout is used when it's nil
+		HTTPClient *http.Client
 	}
 )
 
@@ -34,9 +39,15 @@ func New(cfg Config) (*Client, error) {
 	}
 	cfg.PrivateKey = string(decoded)
 
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
 	return &Client{
-		cfg: cfg,
-		bot: nil,
+		cfg:        cfg,
+		bot:        nil,
+		httpClient: httpClient,
 	}, nil
 }
 
@@ -62,17 +73,17 @@ func (s *Client) GenerateToken() (string, *time.Time, error) {
 		return "", nil, err
 	}
 
-	token, expiredAt, err := getChannelStatelessAccessToken(jwt)
+	token, expiredAt, err := getChannelStatelessAccessToken(s.httpClient, jwt)
 	if err != nil {
 		return "", nil, err
 	}
 
-	s.bot, err = messaging_api.NewMessagingApiAPI(token)
+	s.bot, err = messaging_api.NewMessagingApiAPI(token, messaging_api.WithHTTPClient(s.httpClient))
 	if err != nil {
 		return "", nil, err
 	}
 
-	s.blobBot, err = messaging_api.NewMessagingApiBlobAPI(token)
+	s.blobBot, err = messaging_api.NewMessagingApiBlobAPI(token, messaging_api.WithBlobHTTPClient(s.httpClient))
 	if err != nil {
 		return "", nil, err
 	}
@@ -194,3 +205,36 @@ func (s *Client) SendBroadcaseMessage(ctx context.Context, title, summary, cover
 
 	return nil
 }
+
+func (s *Client) GetProfile(ctx context.Context, userID string) (*messaging_api.UserProfileResponse, error) {
+	profile, err := s.bot.GetProfile(userID)
+	if err != nil {
+		slog.Error("[goutils.line] failed to get profile", "error", err)
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (s *Client) LinkRichMenuToUser(ctx context.Context, userID, richMenuID string) error {
+	if _, err := s.bot.LinkRichMenuIdToUser(userID, richMenuID); err != nil {
+		slog.Error("[goutils.line] failed to link rich menu", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Client) UnlinkRichMenuFromUser(ctx context.Context, userID string) error {
+	if _, err := s.bot.UnlinkRichMenuIdFromUser(userID); err != nil {
+		slog.Error("[goutils.line] failed to unlink rich menu", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Client) SetDefaultRichMenu(ctx context.Context, richMenuID string) error {
+	if _, err := s.bot.SetDefaultRichMenu(richMenuID); err != nil {
+		slog.Error("[goutils.line] failed to set default rich menu", "error", err)
+		return err
+	}
+	return nil
+}
@@ -99,7 +99,7 @@ func GenerateJWKPair() (string, string, error) {
 	return encodedPub, encodedKey, err
 }
 
-func getChannelAccessToken(jwtToken string) (string, *time.Time, error) {
+func getChannelAccessToken(client *http.Client, jwtToken string) (string, *time.Time, error) {
 	// LINE API endpoint for obtaining channel access token
 	const tokenEndpoint = "https://api.line.me/oauth2/v2.1/token"
 
@@ -108,10 +108,10 @@ func getChannelAccessToken(jwtToken string) (string, *time.Time, error) {
 	data.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
 	data.Set("client_assertion", jwtToken)
 
-	return getAccessToken(tokenEndpoint, data)
+	return getAccessToken(client, tokenEndpoint, data)
 }
 
-func getChannelStatelessAccessToken(jwtToken string) (string, *time.Time, error) {
+func getChannelStatelessAccessToken(client *http.Client, jwtToken string) (string, *time.Time, error) {
 	// curl -v -X POST https://api.line.me/oauth2/v3/token \
 	// -H 'Content-Type: application/x-www-form-urlencoded' \
 	// --data-urlencode 'grant_type=client_credentials' \
@@ -124,10 +124,10 @@ func getChannelStatelessAccessToken(jwtToken string) (string, *time.Time, error)
 	data.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
 	data.Set("client_assertion", jwtToken)
 
-	return getAccessToken(tokenEndpoint, data)
+	return getAccessToken(client, tokenEndpoint, data)
 }
 
-func getAccessToken(url string, data url.Values) (string, *time.Time, error) {
+func getAccessToken(client *http.Client, url string, data url.Values) (string, *time.Time, error) {
 	// Create request
 	req, err := http.NewRequest("POST", url, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -135,8 +135,7 @@ func getAccessToken(url string, data url.Values) (string, *time.Time, error) {
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	// Create HTTP client and send request
-	client := &http.Client{}
+	// Send request
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", nil, err
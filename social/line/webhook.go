@@ -0,0 +1,49 @@
+package line
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/line/line-bot-sdk-go/v8/linebot/webhook"
+)
+
+const signatureHeader = "X-Line-Signature"
+
+var ErrInvalidSignature = errors.New("invalid line signature")
+
+// VerifySignature checks the X-Line-Signature of a webhook body, which is the
+// base64 HMAC-SHA256 of the body keyed with the channel secret.
+func VerifySignature(channelSecret string, body []byte, signature string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(channelSecret))
+	mac.Write(body)
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
+
+// ParseWebhookRequest verifies the signature of a webhook request and decodes its events.
+func ParseWebhookRequest(channelSecret string, r *http.Request) (*webhook.CallbackRequest, error) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read line webhook body: %w", err)
+	}
+	if !VerifySignature(channelSecret, body, r.Header.Get(signatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var cb webhook.CallbackRequest
+	if err := json.Unmarshal(body, &cb); err != nil {
+		return nil, fmt.Errorf("failed to decode line webhook events: %w", err)
+	}
+	return &cb, nil
+}
@@ -1,17 +1,18 @@
 package telegram
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type (
 	Client struct {
-		cfg Config
+		cfg    Config
+		client *http.Client
 	}
 
 	Config struct {
@@ -31,14 +32,22 @@ type (
 	}
 )
 
+// New creates a telegram client. An optional http.Client can be passed to
+// route requests through a proxy or set a custom timeout.
 func New(
 	botToken, channelID string,
+	httpClient ...*http.Client,
 ) *Client {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if len(httpClient) > 0 && httpClient[0] != nil {
+		client = httpClient[0]
+	}
 	return &Client{
 		cfg: Config{
 			botToken:  botToken,
 			channelID: channelID,
 		},
+		client: client,
 	}
 }
 
@@ -72,10 +81,12 @@ func (s *Client) SendTextMessage(ctx context.Context, title, text, url string) e
 	if url != "" {
 		smr.Text = fmt.Sprintf("# *%s*\n\n%s\n\n👉 %s\n\n", title, text, url)
 	}
-	return s.SendTextMessageRaw(ctx, smr)
+	_, err := s.SendTextMessageRaw(ctx, smr)
+	return err
 }
 
-func (s *Client) SendTextMessageRaw(ctx context.Context, smr SendMessageReq) error {
+// SendTextMessageRaw sends the message and returns the ID of the created message.
+func (s *Client) SendTextMessageRaw(ctx context.Context, smr SendMessageReq) (int64, error) {
 	if smr.ChatID == "" {
 		smr.ChatID = s.cfg.channelID
 	}
@@ -84,35 +95,30 @@ func (s *Client) SendTextMessageRaw(ctx context.Context, smr SendMessageReq) err
 		smr.ParseMode = "markdown"
 	}
 
-	payload, err := json.Marshal(smr)
-	if err != nil {
-		return err
+	var msg Message
+	if err := s.callAPI(ctx, "sendMessage", smr, &msg); err != nil {
+		return 0, err
 	}
 
-	buf := bytes.NewBuffer(payload)
-	apiUrl := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", s.cfg.botToken)
-	req, err := http.NewRequest(http.MethodPost, apiUrl, buf)
-	if err != nil {
-		return err
-	}
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	var body SendMessageResp
-	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
-		return err
-	}
+	return msg.MessageID, nil
+}
 
-	if !body.Ok {
-		return fmt.Errorf("unsuccessful telegram send message request: %d, %s", body.ErrorCode, body.Description)
-	}
+// EditMessageText replaces the text of a message sent to the channel.
+func (s *Client) EditMessageText(ctx context.Context, messageID int64, text string) error {
+	return s.callAPI(ctx, "editMessageText", map[string]any{
+		"chat_id":    s.cfg.channelID,
+		"message_id": messageID,
+		"text":       text,
+		"parse_mode": "markdown",
+	}, nil)
+}
 
-	return nil
+// DeleteMessage deletes a message sent to the channel.
+func (s *Client) DeleteMessage(ctx context.Context, messageID int64) error {
+	return s.callAPI(ctx, "deleteMessage", map[string]any{
+		"chat_id":    s.cfg.channelID,
+		"message_id": messageID,
+	}, nil)
 }
 
 func (s *Client) VerifyPermission(ctx context.Context) error {
@@ -128,7 +134,7 @@ func (s *Client) VerifyPermission(ctx context.Context) error {
 	}
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
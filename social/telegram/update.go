@@ -0,0 +1,149 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type (
+	User struct {
+		ID           int64  `json:"id"`
+		IsBot        bool   `json:"is_bot"`
+		FirstName    string `json:"first_name"`
+		LastName     string `json:"last_name,omitempty"`
+		Username     string `json:"username,omitempty"`
+		LanguageCode string `json:"language_code,omitempty"`
+	}
+
+	Chat struct {
+		ID       int64  `json:"id"`
+		Type     string `json:"type"`
+		Title    string `json:"title,omitempty"`
+		Username string `json:"username,omitempty"`
+	}
+
+	Message struct {
+		MessageID      int64    `json:"message_id"`
+		From           *User    `json:"from,omitempty"`
+		SenderChat     *Chat    `json:"sender_chat,omitempty"`
+		Chat           Chat     `json:"chat"`
+		Date           int64    `json:"date"`
+		Text           string   `json:"text,omitempty"`
+		Caption        string   `json:"caption,omitempty"`
+		ReplyToMessage *Message `json:"reply_to_message,omitempty"`
+	}
+
+	CallbackQuery struct {
+		ID              string   `json:"id"`
+		From            User     `json:"from"`
+		Message         *Message `json:"message,omitempty"`
+		InlineMessageID string   `json:"inline_message_id,omitempty"`
+		ChatInstance    string   `json:"chat_instance"`
+		Data            string   `json:"data,omitempty"`
+	}
+
+	Update struct {
+		UpdateID          int64          `json:"update_id"`
+		Message           *Message       `json:"message,omitempty"`
+		EditedMessage     *Message       `json:"edited_message,omitempty"`
+		ChannelPost       *Message       `json:"channel_post,omitempty"`
+		EditedChannelPost *Message       `json:"edited_channel_post,omitempty"`
+		CallbackQuery     *CallbackQuery `json:"callback_query,omitempty"`
+	}
+
+	// APIError is returned when the bot API responds with ok=false.
+	APIError struct {
+		Method      string
+		ErrorCode   int
+		Description string
+	}
+
+	apiResponse struct {
+		Ok          bool            `json:"ok"`
+		ErrorCode   int             `json:"error_code,omitempty"`
+		Description string          `json:"description,omitempty"`
+		Result      json.RawMessage `json:"result,omitempty"`
+	}
+
+	SetWebhookReq struct {
+		URL                string   `json:"url"`
+		SecretToken        string   `json:"secret_token,omitempty"`
+		AllowedUpdates     []string `json:"allowed_updates,omitempty"`
+		DropPendingUpdates bool     `json:"drop_pending_updates,omitempty"`
+	}
+)
+
+const webhookSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unsuccessful telegram %s request: %d, %s", e.Method, e.ErrorCode, e.Description)
+}
+
+// ParseWebhookUpdate decodes the update sent by Telegram to a webhook.
+func ParseWebhookUpdate(r *http.Request) (*Update, error) {
+	defer r.Body.Close()
+
+	var update Update
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		return nil, fmt.Errorf("failed to decode telegram update: %w", err)
+	}
+	return &update, nil
+}
+
+// ParseWebhookUpdateWithSecret is like ParseWebhookUpdate but first checks the
+// secret token registered with SetWebhook.
+func ParseWebhookUpdateWithSecret(r *http.Request, secretToken string) (*Update, error) {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(webhookSecretHeader)), []byte(secretToken)) != 1 {
+		return nil, fmt.Errorf("invalid telegram webhook secret token")
+	}
+	return ParseWebhookUpdate(r)
+}
+
+func (s *Client) SetWebhook(ctx context.Context, req SetWebhookReq) error {
+	return s.callAPI(ctx, "setWebhook", req, nil)
+}
+
+func (s *Client) DeleteWebhook(ctx context.Context, dropPendingUpdates bool) error {
+	return s.callAPI(ctx, "deleteWebhook", map[string]any{"drop_pending_updates": dropPendingUpdates}, nil)
+}
+
+// callAPI posts payload to a bot API method and decodes its result into result if not nil.
+func (s *Client) callAPI(ctx context.Context, method string, payload any, result any) error {
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	apiUrl := fmt.Sprintf("https://api.telegram.org/bot%s/%s", s.cfg.botToken, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiUrl, bytes.NewBuffer(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body apiResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if !body.Ok {
+		return &APIError{Method: method, ErrorCode: body.ErrorCode, Description: body.Description}
+	}
+
+	if result != nil && len(body.Result) != 0 {
+		if err := json.Unmarshal(body.Result, result); err != nil {
+			return fmt.Errorf("failed to decode telegram %s result: %w", method, err)
+		}
+	}
+	return nil
+}
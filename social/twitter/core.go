@@ -109,6 +109,15 @@ type (
 			Tweets []TweetObject `json:"tweets"`
 		} `json:"includes"`
 	}
+
+	// ResolvedTweet is a tweet with its author and referenced tweets attached.
+	ResolvedTweet struct {
+		TweetObject
+		Author    *User          `json:"author,omitempty"`
+		Quoted    *ResolvedTweet `json:"quoted,omitempty"`
+		RepliedTo *ResolvedTweet `json:"replied_to,omitempty"`
+		Retweeted *ResolvedTweet `json:"retweeted,omitempty"`
+	}
 )
 
 func (t *TweetObject) HasReferencedTweets() bool {
@@ -161,3 +170,72 @@ func (t *TweetResponse) GetUserByID(id string) *User {
 	}
 	return nil
 }
+
+// Resolve attaches the author and the quoted, replied-to and retweeted tweets
+// found in Includes to the main tweet.
+func (t *TweetResponse) Resolve() *ResolvedTweet {
+	ret := &ResolvedTweet{
+		TweetObject: t.Data,
+		Author:      t.GetUserByID(t.Data.AuthorID),
+	}
+	for _, ref := range t.Data.ReferencedTweets {
+		tweet := t.GetReferencedTweetByID(ref.ID)
+		if tweet == nil {
+			continue
+		}
+		resolved := &ResolvedTweet{
+			TweetObject: *tweet,
+			Author:      t.GetUserByID(tweet.AuthorID),
+		}
+		switch ref.Type {
+		case "quoted":
+			ret.Quoted = resolved
+		case "replied_to":
+			ret.RepliedTo = resolved
+		case "retweeted":
+			ret.Retweeted = resolved
+		}
+	}
+	return ret
+}
+
+// Merge appends the tweets of others to t and deduplicates the includes by ID.
+// Meta.NextToken is taken from the last response.
+func (t *TweetsResponse) Merge(others ...*TweetsResponse) {
+	users := make(map[string]struct{}, len(t.Includes.Users))
+	for _, user := range t.Includes.Users {
+		users[user.ID] = struct{}{}
+	}
+	tweets := make(map[string]struct{}, len(t.Includes.Tweets))
+	for _, tweet := range t.Includes.Tweets {
+		tweets[tweet.ID] = struct{}{}
+	}
+
+	for _, other := range others {
+		if other == nil {
+			continue
+		}
+		t.Data = append(t.Data, other.Data...)
+		for _, user := range other.Includes.Users {
+			if _, ok := users[user.ID]; !ok {
+				users[user.ID] = struct{}{}
+				t.Includes.Users = append(t.Includes.Users, user)
+			}
+		}
+		for _, tweet := range other.Includes.Tweets {
+			if _, ok := tweets[tweet.ID]; !ok {
+				tweets[tweet.ID] = struct{}{}
+				t.Includes.Tweets = append(t.Includes.Tweets, tweet)
+			}
+		}
+		t.Meta.ResultCount += other.Meta.ResultCount
+		t.Meta.NextToken = other.Meta.NextToken
+	}
+}
+
+// MergeTweetsResponses merges the pages of a paginated query into a new response.
+func MergeTweetsResponses(resps ...*TweetsResponse) *TweetsResponse {
+	merged := &TweetsResponse{}
+	merged.Merge(resps...)
+	return merged
+}
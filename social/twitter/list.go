@@ -3,11 +3,14 @@ package twitter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/lyricat/goutils/social/cursor"
 	"golang.org/x/oauth2"
 )
 
@@ -84,6 +87,95 @@ func (c *Client) GetTweetsFromList(ctx context.Context, token *oauth2.Token, lis
 	return &result, nil
 }
 
+// ListTweetsCursor returns a cursor over the pages of tweets of a Twitter List.
+func (c *Client) ListTweetsCursor(token *oauth2.Token, listID string, maxResults int) *cursor.Cursor[*TweetsResponse] {
+	return cursor.New(func(ctx context.Context, paginationToken string) (*TweetsResponse, string, error) {
+		page, err := c.GetTweetsFromList(ctx, token, listID, maxResults, paginationToken)
+		if err != nil {
+			return nil, "", err
+		}
+		return page, page.Meta.NextToken, nil
+	})
+}
+
+// ListMembersCursor returns a cursor over the pages of members of a Twitter List.
+func (c *Client) ListMembersCursor(token *oauth2.Token, listID string, maxResults int) *cursor.Cursor[*ListMemberResponse] {
+	return cursor.New(func(ctx context.Context, paginationToken string) (*ListMemberResponse, string, error) {
+		page, err := c.GetListMembers(ctx, token, listID, maxResults, paginationToken)
+		if err != nil {
+			return nil, "", err
+		}
+		return page, page.Meta.NextToken, nil
+	})
+}
+
+const (
+	listTweetsPageSize       = 100
+	listTweetsMaxRetries     = 3
+	listTweetsDefaultBackoff = time.Minute
+)
+
+// ForEachTweetInList calls fn for every tweet of a Twitter List, following the
+// pagination tokens until the list is exhausted, maxTweets tweets have been seen
+// (no limit if maxTweets <= 0) or fn returns false. When rate limited, it waits
+// until the limit resets and retries the page.
+func (c *Client) ForEachTweetInList(ctx context.Context, token *oauth2.Token, listID string, maxTweets int, fn func(tweet *TweetObject, page *TweetsResponse) bool) error {
+	count := 0
+	paginationToken := ""
+	for {
+		pageSize := listTweetsPageSize
+		if maxTweets > 0 && maxTweets-count < pageSize {
+			// the API rejects max_results below 1
+			pageSize = max(maxTweets-count, 1)
+		}
+
+		page, err := c.getTweetsFromListWithRetry(ctx, token, listID, pageSize, paginationToken)
+		if err != nil {
+			return err
+		}
+
+		for i := range page.Data {
+			if !fn(&page.Data[i], page) {
+				return nil
+			}
+			count++
+			if maxTweets > 0 && count >= maxTweets {
+				return nil
+			}
+		}
+
+		paginationToken = page.Meta.NextToken
+		if paginationToken == "" || len(page.Data) == 0 {
+			return nil
+		}
+	}
+}
+
+func (c *Client) getTweetsFromListWithRetry(ctx context.Context, token *oauth2.Token, listID string, maxResults int, paginationToken string) (*TweetsResponse, error) {
+	for attempt := 0; ; attempt++ {
+		page, err := c.GetTweetsFromList(ctx, token, listID, maxResults, paginationToken)
+		if err == nil {
+			return page, nil
+		}
+
+		var rlErr *RateLimitError
+		if !errors.As(err, &rlErr) || attempt >= listTweetsMaxRetries {
+			return nil, err
+		}
+
+		wait := listTweetsDefaultBackoff
+		if !rlErr.Reset.IsZero() {
+			wait = time.Until(rlErr.Reset)
+		}
+		slog.Warn("rate limited while getting tweets from list, waiting", "list_id", listID, "wait", wait)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
 // GetListMembers retrieves members of a Twitter List
 func (c *Client) GetListMembers(ctx context.Context, token *oauth2.Token, listID string, maxResults int, paginationToken string) (*ListMemberResponse, error) {
 	url := fmt.Sprintf("https://api.x.com/2/lists/%s/members", listID)
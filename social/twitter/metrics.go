@@ -0,0 +1,119 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type (
+	TweetNonPublicMetrics struct {
+		ImpressionCount   int64 `json:"impression_count"`
+		URLLinkClicks     int64 `json:"url_link_clicks"`
+		UserProfileClicks int64 `json:"user_profile_clicks"`
+	}
+
+	TweetOrganicMetrics struct {
+		ImpressionCount   int64 `json:"impression_count"`
+		LikeCount         int64 `json:"like_count"`
+		ReplyCount        int64 `json:"reply_count"`
+		RetweetCount      int64 `json:"retweet_count"`
+		URLLinkClicks     int64 `json:"url_link_clicks"`
+		UserProfileClicks int64 `json:"user_profile_clicks"`
+	}
+
+	// TweetMetrics is a snapshot of a tweet's metrics. NonPublic and Organic are
+	// only set for the token owner's own tweets of the last 30 days.
+	TweetMetrics struct {
+		TweetID   string                 `json:"tweet_id"`
+		Public    TweetPublicMetrics     `json:"public"`
+		NonPublic *TweetNonPublicMetrics `json:"non_public,omitempty"`
+		Organic   *TweetOrganicMetrics   `json:"organic,omitempty"`
+		FetchedAt time.Time              `json:"fetched_at"`
+	}
+)
+
+// GetTweetMetrics fetches only the metrics of a tweet. Set includePrivate for the
+// token owner's own tweets to also get the non-public and organic metrics; it needs
+// a user token and falls back to the public metrics if the API refuses them.
+func (c *Client) GetTweetMetrics(ctx context.Context, token *oauth2.Token, tweetID string, includePrivate bool) (*TweetMetrics, error) {
+	if includePrivate && c.cfg.BearerToken == "" {
+		metrics, err := c.getTweetMetrics(ctx, token, tweetID, "public_metrics,non_public_metrics,organic_metrics")
+		if err == nil {
+			return metrics, nil
+		}
+		var rlErr *RateLimitError
+		if errors.As(err, &rlErr) {
+			return nil, err
+		}
+		slog.Warn("failed to get private tweet metrics, falling back to public metrics", "tweet", tweetID, "error", err)
+	}
+	return c.getTweetMetrics(ctx, token, tweetID, "public_metrics")
+}
+
+func (c *Client) getTweetMetrics(ctx context.Context, token *oauth2.Token, tweetID, fields string) (*TweetMetrics, error) {
+	url := fmt.Sprintf("https://api.x.com/2/tweets/%s", tweetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("tweet.fields", fields)
+	req.URL.RawQuery = q.Encode()
+
+	c.addAuthHeader(req, token)
+
+	client := c.getHTTPClient(ctx, token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if err := c.catchError(resp, body); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *struct {
+			ID               string                 `json:"id"`
+			PublicMetrics    TweetPublicMetrics     `json:"public_metrics"`
+			NonPublicMetrics *TweetNonPublicMetrics `json:"non_public_metrics"`
+			OrganicMetrics   *TweetOrganicMetrics   `json:"organic_metrics"`
+		} `json:"data"`
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	if result.Data == nil {
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("twitter API error: %s, %s", result.Errors[0].Title, result.Errors[0].Detail)
+		}
+		return nil, fmt.Errorf("tweet %s not found", tweetID)
+	}
+
+	return &TweetMetrics{
+		TweetID:   result.Data.ID,
+		Public:    result.Data.PublicMetrics,
+		NonPublic: result.Data.NonPublicMetrics,
+		Organic:   result.Data.OrganicMetrics,
+		FetchedAt: time.Now(),
+	}, nil
+}
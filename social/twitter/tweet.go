@@ -22,7 +22,7 @@ func (c *Client) GetTweetByID(ctx context.Context, token *oauth2.Token, tweetID
 	q := req.URL.Query()
 	q.Add("tweet.fields", "author_id,created_at,entities,public_metrics,referenced_tweets,lang")
 	q.Add("user.fields", "id,name,profile_image_url,username,public_metrics")
-	q.Add("expansions", "author_id,referenced_tweets.id")
+	q.Add("expansions", "author_id,referenced_tweets.id,referenced_tweets.id.author_id")
 	req.URL.RawQuery = q.Encode()
 
 	c.addAuthHeader(req, token)
@@ -52,6 +52,15 @@ func (c *Client) GetTweetByID(ctx context.Context, token *oauth2.Token, tweetID
 	return &result, nil
 }
 
+// GetResolvedTweetByID returns the tweet with its author and referenced tweets resolved.
+func (c *Client) GetResolvedTweetByID(ctx context.Context, token *oauth2.Token, tweetID string) (*ResolvedTweet, error) {
+	result, err := c.GetTweetByID(ctx, token, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	return result.Resolve(), nil
+}
+
 func (c *Client) GetTweetsByIDs(ctx context.Context, token *oauth2.Token, tweetIDs []string) (*TweetsResponse, error) {
 	url := "https://api.twitter.com/2/tweets"
 	req, err := http.NewRequest("GET", url, nil)
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lyricat/goutils/cache"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/exp/rand"
 	"golang.org/x/oauth2"
@@ -21,8 +22,9 @@ type (
 	Client struct {
 		cfg         Config
 		oauthConfig *oauth2.Config
-		rdb         *redis.Client
+		cache       *cache.Cache
 		httpClient  *http.Client
+		transport   *http.Transport
 	}
 	Config struct {
 		BearerToken  string
@@ -57,20 +59,37 @@ func New(cfg Config, rdb *redis.Client) *Client {
 		Endpoint:     twitterEndpoint,
 	}
 
+	// don't share http.DefaultTransport, so Close only touches our connections
+	transport := http.DefaultTransport.(*http.Transport).Clone()
 	return &Client{
 		cfg:         cfg,
 		oauthConfig: oauthConfig,
-		rdb:         rdb,
-		httpClient:  &http.Client{},
+		cache:       cache.New(rdb, "user_token:twitter"),
+		httpClient:  &http.Client{Transport: transport},
+		transport:   transport,
 	}
 }
 
+// Close releases the idle connections of the client, it implements io.Closer.
+// The redis client passed to New is owned by the caller and is left open.
+func (c *Client) Close() error {
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
 func (c *Client) ExchangeTokensWithCode(ctx context.Context, code, state string) (*oauth2.Token, error) {
-	key := fmt.Sprintf("user_token:twitter:%s", state)
-	codeVerifier, err := c.rdb.Get(ctx, key).Result()
+	raw, found, err := c.cache.GetRaw(ctx, state)
 	if err != nil {
 		return nil, err
 	}
+	if !found {
+		return nil, fmt.Errorf("code verifier not found for state: %s", state)
+	}
+	var codeVerifier string
+	if err := json.Unmarshal(raw, &codeVerifier); err != nil {
+		// written as a plain string before the verifier was JSON encoded
+		codeVerifier = string(raw)
+	}
 
 	opts := []oauth2.AuthCodeOption{
 		oauth2.SetAuthURLParam("code_verifier", codeVerifier),
@@ -81,7 +100,7 @@ func (c *Client) ExchangeTokensWithCode(ctx context.Context, code, state string)
 		return nil, err
 	}
 
-	c.rdb.Del(ctx, key)
+	c.cache.Del(ctx, state)
 
 	return token, nil
 }
@@ -90,8 +109,7 @@ func (c *Client) GetAuthURL(ctx context.Context, state string) string {
 	codeVerifier := generateCodeVerifier()
 	codeChallenge := generateCodeChallenge(codeVerifier)
 
-	key := fmt.Sprintf("user_token:twitter:%s", state)
-	c.rdb.Set(ctx, key, codeVerifier, time.Minute*3)
+	c.cache.Set(ctx, state, codeVerifier, time.Minute*3)
 
 	// with RedirectURL, code_challenge and code_challenge_method
 	opts := []oauth2.AuthCodeOption{
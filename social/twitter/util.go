@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -49,7 +51,25 @@ func (c *Client) ValidateToken(ctx context.Context, token *oauth2.Token) error {
 	return nil
 }
 
+// RateLimitError is returned when the API responds with 429 Too Many Requests.
+type RateLimitError struct {
+	// Reset is when the rate limit window resets, zero if unknown
+	Reset time.Time
+	Body  string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("twitter API rate limited until %s, body: %s", e.Reset.Format(time.RFC3339), e.Body)
+}
+
 func (c *Client) catchError(resp *http.Response, body []byte) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rlErr := &RateLimitError{Body: string(body)}
+		if reset, err := strconv.ParseInt(resp.Header.Get("x-rate-limit-reset"), 10, 64); err == nil {
+			rlErr.Reset = time.Unix(reset, 0)
+		}
+		return rlErr
+	}
 	if resp.StatusCode != http.StatusOK {
 		fmt.Printf("Response Status: %s\n", resp.Status)
 		fmt.Printf("Response Body: %s\n", string(body))
@@ -11,17 +11,25 @@ import (
 
 type (
 	Client struct {
-		cfg Config
+		cfg    Config
+		client *http.Client
 	}
 	Config struct {
 		AzureAPIKey   string
 		AzureEndpoint string
+		// HTTPClient is used for all requests, a client with a 30s timeout is used when it's nil
+		HTTPClient *http.Client
 	}
 )
 
 func New(cfg Config) *Client {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: time.Second * 30}
+	}
 	return &Client{
-		cfg: cfg,
+		cfg:    cfg,
+		client: client,
 	}
 }
 
@@ -35,8 +43,7 @@ func (d *Client) ToText(langCode string, audioData []byte) (string, error) {
 	req.Header.Set("Ocp-Apim-Subscription-Key", d.cfg.AzureAPIKey)
 	req.Header.Set("Content-Type", "audio/wav; codec=audio/pcm; samplerate=16000")
 
-	client := &http.Client{Timeout: time.Second * 30}
-	resp, err := client.Do(req)
+	resp, err := d.client.Do(req)
 	if err != nil {
 		return "", err
 	}
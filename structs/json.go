@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 )
 
 type (
@@ -104,10 +106,19 @@ func (a *JSONMap) GetInt64(key string) int64 {
 	return 0
 }
 
+// GetBool also accepts "true"/"false"-like strings and numbers, which are true when non-zero.
 func (a *JSONMap) GetBool(key string) bool {
 	if val, ok := (*a)[key]; ok {
-		if boolVal, ok := val.(bool); ok {
+		switch v := val.(type) {
+		case bool:
+			return v
+		case string:
+			boolVal, _ := strconv.ParseBool(v)
 			return boolVal
+		default:
+			if num, ok := toFloat64(val); ok {
+				return num != 0
+			}
 		}
 	}
 	return false
@@ -126,15 +137,150 @@ func (a *JSONMap) GetMap(key string) *JSONMap {
 	return &ret
 }
 
+// GetFloat64 also accepts integers and numeric strings.
 func (a *JSONMap) GetFloat64(key string) float64 {
 	if val, ok := (*a)[key]; ok {
-		if floatVal, ok := val.(float64); ok {
+		if floatVal, ok := toFloat64(val); ok {
+			return floatVal
+		}
+		if strVal, ok := val.(string); ok {
+			floatVal, _ := strconv.ParseFloat(strVal, 64)
 			return floatVal
 		}
 	}
 	return 0
 }
 
+func toFloat64(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
 func (a *JSONMap) SetValue(key string, value interface{}) {
 	(*a)[key] = value
 }
+
+// Clone returns a deep copy of the map, nested maps and lists are copied too.
+func (a JSONMap) Clone() JSONMap {
+	if a == nil {
+		return nil
+	}
+	ret := make(JSONMap, len(a))
+	for k, v := range a {
+		ret[k] = cloneValue(v)
+	}
+	return ret
+}
+
+func cloneValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case JSONMap:
+		return val.Clone()
+	case map[string]interface{}:
+		return map[string]interface{}(JSONMap(val).Clone())
+	case JSONList:
+		if val == nil {
+			return val
+		}
+		ret := make(JSONList, len(val))
+		for i, item := range val {
+			ret[i] = cloneValue(item)
+		}
+		return ret
+	case []interface{}:
+		if val == nil {
+			return val
+		}
+		ret := make([]interface{}, len(val))
+		for i, item := range val {
+			ret[i] = cloneValue(item)
+		}
+		return ret
+	default:
+		return v
+	}
+}
+
+// Pick returns a new map with only the given keys. A key can be a dotted path
+// such as "user.name" to pick a nested field.
+func (a JSONMap) Pick(keys ...string) JSONMap {
+	ret := NewJSONMap()
+	for _, key := range keys {
+		path := strings.Split(key, ".")
+		val, ok := lookupPath(a, path)
+		if !ok {
+			continue
+		}
+		m := ret
+		for _, p := range path[:len(path)-1] {
+			next, ok := m[p].(JSONMap)
+			if !ok {
+				next = NewJSONMap()
+				m[p] = next
+			}
+			m = next
+		}
+		m[path[len(path)-1]] = cloneValue(val)
+	}
+	return ret
+}
+
+// Omit returns a copy of the map without the given keys. A key can be a dotted
+// path such as "user.email" to drop a nested field.
+func (a JSONMap) Omit(keys ...string) JSONMap {
+	ret := a.Clone()
+	if ret == nil {
+		return NewJSONMap()
+	}
+	for _, key := range keys {
+		path := strings.Split(key, ".")
+		parent, ok := lookupPath(ret, path[:len(path)-1])
+		if !ok {
+			continue
+		}
+		if m, ok := asMap(parent); ok {
+			delete(m, path[len(path)-1])
+		}
+	}
+	return ret
+}
+
+func lookupPath(m JSONMap, path []string) (interface{}, bool) {
+	var cur interface{} = m
+	for _, p := range path {
+		curMap, ok := asMap(cur)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = curMap[p]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	switch val := v.(type) {
+	case JSONMap:
+		return val, true
+	case map[string]interface{}:
+		return val, true
+	}
+	return nil, false
+}
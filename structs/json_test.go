@@ -0,0 +1,90 @@
+package structs
+
+import "testing"
+
+func TestJSONMapClone(t *testing.T) {
+	orig := JSONMap{
+		"name":   "a",
+		"nested": map[string]interface{}{"k": "v"},
+		"json":   JSONMap{"k": "v"},
+		"list":   []interface{}{map[string]interface{}{"k": "v"}},
+	}
+
+	clone := orig.Clone()
+	clone["name"] = "b"
+	clone["nested"].(map[string]interface{})["k"] = "changed"
+	clone["json"].(JSONMap)["k"] = "changed"
+	clone["list"].([]interface{})[0].(map[string]interface{})["k"] = "changed"
+
+	if orig["name"] != "a" {
+		t.Errorf("name changed: %v", orig["name"])
+	}
+	if orig["nested"].(map[string]interface{})["k"] != "v" {
+		t.Errorf("nested map changed")
+	}
+	if orig["json"].(JSONMap)["k"] != "v" {
+		t.Errorf("nested JSONMap changed")
+	}
+	if orig["list"].([]interface{})[0].(map[string]interface{})["k"] != "v" {
+		t.Errorf("map in list changed")
+	}
+}
+
+func TestJSONMapPickOmit(t *testing.T) {
+	m := JSONMap{
+		"id": 1,
+		"user": map[string]interface{}{
+			"name":  "a",
+			"email": "a@example.com",
+		},
+	}
+
+	picked := m.Pick("id", "user.name", "missing", "user.missing")
+	if len(picked) != 2 || picked["id"] != 1 {
+		t.Errorf("unexpected picked map: %v", picked)
+	}
+	user, ok := picked["user"].(JSONMap)
+	if !ok || len(user) != 1 || user["name"] != "a" {
+		t.Errorf("unexpected picked user: %v", picked["user"])
+	}
+
+	omitted := m.Omit("id", "user.email", "missing.key")
+	if _, ok := omitted["id"]; ok {
+		t.Errorf("id not omitted: %v", omitted)
+	}
+	if u := omitted["user"].(map[string]interface{}); len(u) != 1 || u["name"] != "a" {
+		t.Errorf("unexpected omitted user: %v", u)
+	}
+	if len(m["user"].(map[string]interface{})) != 2 {
+		t.Errorf("original map changed: %v", m)
+	}
+}
+
+func TestJSONMapCoercion(t *testing.T) {
+	m := JSONMap{
+		"float":     1.5,
+		"int":       2,
+		"int64":     int64(3),
+		"str":       "4.5",
+		"bad":       "x",
+		"true":      true,
+		"true_str":  "true",
+		"false_str": "false",
+		"one":       1,
+		"zero":      0.0,
+	}
+
+	floats := map[string]float64{"float": 1.5, "int": 2, "int64": 3, "str": 4.5, "bad": 0, "missing": 0}
+	for key, want := range floats {
+		if got := m.GetFloat64(key); got != want {
+			t.Errorf("GetFloat64(%q) = %v, want %v", key, got, want)
+		}
+	}
+
+	bools := map[string]bool{"true": true, "true_str": true, "false_str": false, "one": true, "zero": false, "bad": false, "missing": false}
+	for key, want := range bools {
+		if got := m.GetBool(key); got != want {
+			t.Errorf("GetBool(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
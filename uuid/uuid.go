@@ -2,7 +2,9 @@ package uuid
 
 import (
 	"crypto/md5"
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -11,6 +13,28 @@ func New() string {
 	return uuid.Must(uuid.NewV4()).String()
 }
 
+// NewV7 returns a time-sortable UUIDv7, prefixed with the unix-ms timestamp.
+func NewV7() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// TimestampFromV7 extracts the embedded creation time from a UUIDv7.
+func TimestampFromV7(id string) (time.Time, error) {
+	uid, err := FromString(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if uid.Version() != uuid.V7 {
+		return time.Time{}, fmt.Errorf("uuid %s is version %d, not 7", id, uid.Version())
+	}
+
+	var ms int64
+	for i := 0; i < 6; i++ {
+		ms = ms<<8 | int64(uid[i])
+	}
+	return time.UnixMilli(ms), nil
+}
+
 func IsUUID(id string) bool {
 	_, err := FromString(id)
 	return err == nil
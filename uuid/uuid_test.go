@@ -0,0 +1,28 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewV7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := NewV7()
+	after := time.Now()
+
+	if !IsUUID(id) {
+		t.Fatalf("NewV7() = %s, not a valid uuid", id)
+	}
+
+	ts, err := TimestampFromV7(id)
+	if err != nil {
+		t.Fatalf("TimestampFromV7(%s) error: %v", id, err)
+	}
+	if ts.Before(before) || ts.After(after) {
+		t.Errorf("TimestampFromV7(%s) = %v, want between %v and %v", id, ts, before, after)
+	}
+
+	if _, err := TimestampFromV7(New()); err == nil {
+		t.Error("TimestampFromV7() on a v4 uuid should fail")
+	}
+}